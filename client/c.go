@@ -0,0 +1,333 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm"
+	internal_avax "github.com/ava-labs/subnet-cli/internal/avax"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"go.uber.org/zap"
+)
+
+// ref. "evm.codecVersion"
+const evmCodecVersion = 0
+
+// C-Chain balances are denominated in wei (10^18), while
+// AVAX on the X/P-Chain is denominated in nano-AVAX (10^9).
+// ref. "evm.x2cRate"
+var x2cRate = big.NewInt(1_000_000_000)
+
+type C interface {
+	Client() evm.Client
+	EthClient() ethclient.Client
+	// Balance returns the AVAX balance of the key on the C-Chain
+	// in nano-AVAX.
+	Balance(ctx context.Context, k key.Key) (uint64, error)
+	// Export exports [amount] AVAX to the key on [chainID].
+	Export(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		amount uint64,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+	// Import imports all AVAX exported to the key from [chainID].
+	Import(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+}
+
+type c struct {
+	cfg       Config
+	networkID uint32
+	assetID   ids.ID
+	cChainID  ids.ID
+
+	cli    evm.Client
+	eth    ethclient.Client
+	poller poll.Poller
+}
+
+func (cc *c) Client() evm.Client          { return cc.cli }
+func (cc *c) EthClient() ethclient.Client { return cc.eth }
+
+func (cc *c) Balance(ctx context.Context, k key.Key) (uint64, error) {
+	ethAddr, err := k.EthAddress()
+	if err != nil {
+		return 0, err
+	}
+	wei, err := cc.eth.BalanceAt(ctx, ethAddr, nil)
+	if err != nil {
+		return 0, err
+	}
+	return new(big.Int).Div(wei, x2cRate).Uint64(), nil
+}
+
+// ref. "evm.VM.newExportTx".
+func (cc *c) Export(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	amount uint64,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	ethAddr, err := k.EthAddress()
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	nonce, err := cc.eth.NonceAt(ctx, ethAddr, nil)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	baseFee, err := cc.eth.EstimateBaseFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	utx := &evm.UnsignedExportTx{
+		NetworkID:        cc.networkID,
+		BlockchainID:     cc.cChainID,
+		DestinationChain: chainID,
+		Ins: []evm.EVMInput{{
+			Address: ethAddr,
+			Amount:  amount,
+			AssetID: cc.assetID,
+			Nonce:   nonce,
+		}},
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: cc.assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{k.Addresses()[0]},
+				},
+			},
+		}},
+	}
+	// the EVM input is signed by the primary key
+	signers := [][]ids.ShortID{{k.Addresses()[0]}}
+
+	// the fee does not change the size of the tx,
+	// so it can be computed before it is added to the input
+	txFee, err := atomicTxFee(utx, signers, baseFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	utx.Ins[0].Amount, err = math.Add64(amount, txFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	balance, err := cc.Balance(ctx, k)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if balance < utx.Ins[0].Amount {
+		return ids.Empty, 0, fmt.Errorf("%w (have %d, expected %d)", ErrInsufficientBalance, balance, utx.Ins[0].Amount)
+	}
+
+	zap.L().Info("exporting from C-Chain",
+		zap.String("destinationChain", chainID.String()),
+		zap.String("from", ethAddr.Hex()),
+		zap.Uint64("amount", amount),
+		zap.Uint64("txFee", txFee),
+	)
+	return cc.issue(ctx, k, &evm.Tx{UnsignedAtomicTx: utx}, signers)
+}
+
+// ref. "evm.VM.newImportTxWithUTXOs".
+func (cc *c) Import(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	ethAddr, err := k.EthAddress()
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	baseFee, err := cc.eth.EstimateBaseFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	utxos, err := cc.atomicUTXOs(ctx, k, chainID)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	imported, ins, signers := spendAtomicAVAX(k, utxos, cc.assetID)
+	if len(ins) == 0 {
+		return ids.Empty, 0, ErrNoAtomicUTXOs
+	}
+
+	utx := &evm.UnsignedImportTx{
+		NetworkID:      cc.networkID,
+		BlockchainID:   cc.cChainID,
+		SourceChain:    chainID,
+		ImportedInputs: ins,
+		Outs: []evm.EVMOutput{{
+			Address: ethAddr,
+			Amount:  imported,
+			AssetID: cc.assetID,
+		}},
+	}
+	txFee, err := atomicTxFee(utx, signers, baseFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if imported <= txFee {
+		return ids.Empty, 0, fmt.Errorf("%w (imported %d, expected >%d)", ErrInsufficientBalanceForGasFee, imported, txFee)
+	}
+	utx.Outs[0].Amount = imported - txFee
+
+	zap.L().Info("importing to C-Chain",
+		zap.String("sourceChain", chainID.String()),
+		zap.String("to", ethAddr.Hex()),
+		zap.Uint64("imported", imported),
+		zap.Uint64("txFee", txFee),
+	)
+	return cc.issue(ctx, k, &evm.Tx{UnsignedAtomicTx: utx}, signers)
+}
+
+// atomicUTXOs returns the atomic UTXOs exported to the key from [sourceChainID].
+func (cc *c) atomicUTXOs(ctx context.Context, k key.Key, sourceChainID ids.ID) ([]*avax.UTXO, error) {
+	hrp := constants.GetHRP(cc.networkID)
+	return internal_avax.GetAllUTXOs(ctx, k.Addresses(), func(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		limit uint32,
+		startAddr ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		// the C-Chain API takes and returns formatted addresses and IDs
+		addrStrs := make([]string, len(addrs))
+		for i, addr := range addrs {
+			var err error
+			addrStrs[i], err = address.Format("C", hrp, addr[:])
+			if err != nil {
+				return nil, ids.ShortEmpty, ids.Empty, err
+			}
+		}
+		startAddrStr, startUTXOIDStr := "", ""
+		if startAddr != ids.ShortEmpty {
+			var err error
+			startAddrStr, err = address.Format("C", hrp, startAddr[:])
+			if err != nil {
+				return nil, ids.ShortEmpty, ids.Empty, err
+			}
+			startUTXOIDStr = startUTXOID.String()
+		}
+		ubs, end, err := cc.cli.GetAtomicUTXOs(ctx, addrStrs, sourceChainID.String(), limit, startAddrStr, startUTXOIDStr)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, err
+		}
+		if len(ubs) < int(limit) {
+			// no more pages to follow
+			return ubs, ids.ShortEmpty, ids.Empty, nil
+		}
+		endAddr, err := address.ParseToID(end.Address)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, err
+		}
+		endUTXOID, err := ids.FromString(end.UTXO)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, err
+		}
+		return ubs, endAddr, endUTXOID, nil
+	}, evm.Codec)
+}
+
+// issue signs [tx] with [k], issues it, and waits for it to be accepted.
+func (cc *c) issue(
+	ctx context.Context,
+	k key.Key,
+	tx *evm.Tx,
+	signers [][]ids.ShortID,
+) (txID ids.ID, took time.Duration, err error) {
+	unsignedBytes, err := evm.Codec.Marshal(evmCodecVersion, &tx.UnsignedAtomicTx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	sigs, err := key.SignSigners(k, hashing.ComputeHash256(unsignedBytes), signers)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	creds, err := key.Credentials(signers, sigs)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	for _, cred := range creds {
+		tx.Creds = append(tx.Creds, cred)
+	}
+	signedBytes, err := evm.Codec.Marshal(evmCodecVersion, tx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	tx.Initialize(unsignedBytes, signedBytes)
+
+	txID, err = cc.cli.IssueTx(ctx, tx.SignedBytes())
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	zap.L().Info("polling C-Chain atomic tx", zap.String("txId", txID.String()))
+	var status evm.Status
+	took, err = cc.poller.Poll(ctx, func() (done bool, err error) {
+		status, err = cc.cli.GetAtomicTxStatus(ctx, txID)
+		if err != nil {
+			return false, err
+		}
+		return status == evm.Accepted || status == evm.Dropped, nil
+	})
+	if err == nil && status != evm.Accepted {
+		err = fmt.Errorf("%w (status %s)", ErrTxNotAccepted, status)
+	}
+	return txID, took, err
+}
+
+// atomicTxFee returns the AVAX to burn for [utx] signed by [signers]
+// at [baseFee].
+//
+// ref. "evm.UnsignedExportTx.GasUsed", "evm.UnsignedImportTx.GasUsed".
+func atomicTxFee(utx evm.UnsignedAtomicTx, signers [][]ids.ShortID, baseFee *big.Int) (uint64, error) {
+	unsignedBytes, err := evm.Codec.Marshal(evmCodecVersion, &utx)
+	if err != nil {
+		return 0, err
+	}
+	numSigs := uint64(0)
+	for _, inputSigners := range signers {
+		numSigs += uint64(len(inputSigners))
+	}
+	gas := uint64(len(unsignedBytes))*evm.TxBytesGas +
+		numSigs*secp256k1fx.CostPerSignature +
+		params.AtomicTxBaseCost
+
+	// round up to the next nano-AVAX
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), baseFee)
+	fee.Add(fee, new(big.Int).Sub(x2cRate, big.NewInt(1)))
+	fee.Div(fee, x2cRate)
+	if !fee.IsUint64() {
+		return 0, fmt.Errorf("fee %s overflows uint64", fee)
+	}
+	return fee.Uint64(), nil
+}
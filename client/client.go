@@ -15,6 +15,8 @@ import (
 	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
 	internal_platformvm "github.com/ava-labs/subnet-cli/internal/platformvm"
 	"github.com/ava-labs/subnet-cli/internal/poll"
 	"go.uber.org/zap"
@@ -36,10 +38,14 @@ var _ Client = &client{}
 
 type Client interface {
 	NetworkID() uint32
+	XChainID() ids.ID
+	CChainID() ids.ID
 	Config() Config
 	Info() Info
 	KeyStore() KeyStore
 	P() P
+	X() X
+	C() C
 }
 
 type client struct {
@@ -50,11 +56,14 @@ type client struct {
 	networkID   uint32
 	assetID     ids.ID
 	xChainID    ids.ID
+	cChainID    ids.ID
 	pChainID    ids.ID
 
 	i *info
 	k *keyStore
 	p *p
+	x *x
+	c *c
 }
 
 func New(cfg Config) (Client, error) {
@@ -86,6 +95,14 @@ func New(cfg Config) (Client, error) {
 	cli.xChainID = xChainID
 	zap.L().Info("fetched X-Chain id", zap.String("id", cli.xChainID.String()))
 
+	zap.L().Info("fetching C-Chain id")
+	cChainID, err := cli.i.Client().GetBlockchainID(context.TODO(), "C")
+	if err != nil {
+		return nil, err
+	}
+	cli.cChainID = cChainID
+	zap.L().Info("fetched C-Chain id", zap.String("id", cli.cChainID.String()))
+
 	uriX := u.Scheme + "://" + u.Host
 	xChainName := cli.xChainID.String()
 	if u.Port() == "" {
@@ -138,13 +155,45 @@ func New(cfg Config) (Client, error) {
 			pc,
 		),
 	}
+	cli.x = &x{
+		cfg:       cfg,
+		networkID: cli.networkID,
+		assetID:   cli.assetID,
+		xChainID:  cli.xChainID,
+
+		cli:    xc,
+		info:   cli.i.Client(),
+		poller: poll.New(cfg.PollInterval),
+	}
+
+	// e.g., https://api.avax-test.network/ext/bc/C/rpc
+	// ref. https://docs.avax.network/apis/avalanchego/apis/c-chain
+	uriC := u.Scheme + "://" + u.Host
+	eth, err := ethclient.Dial(uriC + "/ext/bc/C/rpc")
+	if err != nil {
+		return nil, err
+	}
+	cli.c = &c{
+		cfg:       cfg,
+		networkID: cli.networkID,
+		assetID:   cli.assetID,
+		cChainID:  cli.cChainID,
+
+		cli:    evm.NewCChainClient(uriC),
+		eth:    eth,
+		poller: poll.New(cfg.PollInterval),
+	}
 	return cli, nil
 }
 
 func (cc *client) NetworkID() uint32 { return cc.networkID }
+func (cc *client) XChainID() ids.ID  { return cc.xChainID }
+func (cc *client) CChainID() ids.ID  { return cc.cChainID }
 func (cc *client) Config() Config    { return cc.cfg }
 
 func (cc *client) Info() Info         { return cc.i }
 func (cc *client) KeyStore() KeyStore { return cc.k }
 
 func (cc *client) P() P { return cc.p }
+func (cc *client) X() X { return cc.x }
+func (cc *client) C() C { return cc.c }
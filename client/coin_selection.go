@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+var (
+	ErrInvalidCoinSelection = errors.New("invalid coin selection")
+	ErrInvalidUTXOID        = errors.New("invalid UTXO ID")
+	ErrUTXONotFound         = errors.New("UTXO not found")
+)
+
+// CoinSelection is the order in which UTXOs are consumed
+// to fund a transaction.
+type CoinSelection string
+
+const (
+	// CoinSelectionDefault consumes UTXOs in the order the API returns them.
+	CoinSelectionDefault CoinSelection = ""
+	// CoinSelectionLargestFirst consumes the largest UTXOs first.
+	CoinSelectionLargestFirst CoinSelection = "largest-first"
+	// CoinSelectionSmallestFirst consumes the smallest UTXOs first,
+	// consolidating dust.
+	CoinSelectionSmallestFirst CoinSelection = "smallest-first"
+	// CoinSelectionMinimizeInputs consumes the smallest UTXO that covers
+	// the whole amount, or the largest UTXOs first if there is none.
+	CoinSelectionMinimizeInputs CoinSelection = "minimize-inputs"
+)
+
+var coinSelections = []CoinSelection{
+	CoinSelectionLargestFirst,
+	CoinSelectionSmallestFirst,
+	CoinSelectionMinimizeInputs,
+}
+
+// ParseCoinSelection parses a coin selection name.
+// An empty string selects [CoinSelectionDefault].
+func ParseCoinSelection(s string) (CoinSelection, error) {
+	if s == "" {
+		return CoinSelectionDefault, nil
+	}
+	for _, cs := range coinSelections {
+		if string(cs) == s {
+			return cs, nil
+		}
+	}
+	return CoinSelectionDefault, fmt.Errorf("%w %q (expected one of %v)", ErrInvalidCoinSelection, s, coinSelections)
+}
+
+// ParseUTXOID parses a UTXO ID formatted as "[TX-ID]:[OUTPUT-INDEX]"
+// and returns its input ID.
+func ParseUTXOID(s string) (ids.ID, error) {
+	idx := strings.LastIndex(s, ":")
+	if idx < 0 {
+		return ids.Empty, fmt.Errorf("%w %q (expected [TX-ID]:[OUTPUT-INDEX])", ErrInvalidUTXOID, s)
+	}
+	txID, err := ids.FromString(s[:idx])
+	if err != nil {
+		return ids.Empty, fmt.Errorf("%w %q (%v)", ErrInvalidUTXOID, s, err)
+	}
+	outputIndex, err := strconv.ParseUint(s[idx+1:], 10, 32)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("%w %q (%v)", ErrInvalidUTXOID, s, err)
+	}
+	utxoID := &avax.UTXOID{TxID: txID, OutputIndex: uint32(outputIndex)}
+	return utxoID.InputID(), nil
+}
+
+// selectUTXOs filters [utxos] by the allowed and excluded UTXO IDs
+// and orders them by the coin selection, to consume [amount].
+func (op *Op) selectUTXOs(utxos []*avax.UTXO, amount uint64) ([]*avax.UTXO, error) {
+	selected, err := op.filterUTXOs(utxos)
+	if err != nil {
+		return nil, err
+	}
+	op.orderUTXOs(selected, amount)
+	return selected, nil
+}
+
+// selectAssetUTXOs is "selectUTXOs" for the transactions that consume
+// several assets (e.g., a stake asset and the AVAX fee): the UTXOs of each
+// asset of [amounts] are ordered to consume its own amount, and the UTXOs
+// of other assets are dropped.
+func (op *Op) selectAssetUTXOs(utxos []*avax.UTXO, amounts map[ids.ID]uint64) ([]*avax.UTXO, error) {
+	filtered, err := op.filterUTXOs(utxos)
+	if err != nil {
+		return nil, err
+	}
+	byAsset := make(map[ids.ID][]*avax.UTXO, len(amounts))
+	for _, utxo := range filtered {
+		assetID := utxo.AssetID()
+		if _, ok := amounts[assetID]; ok {
+			byAsset[assetID] = append(byAsset[assetID], utxo)
+		}
+	}
+	assetIDs := make([]ids.ID, 0, len(amounts))
+	for assetID := range amounts {
+		assetIDs = append(assetIDs, assetID)
+	}
+	ids.SortIDs(assetIDs)
+	selected := make([]*avax.UTXO, 0, len(filtered))
+	for _, assetID := range assetIDs {
+		assetUTXOs := byAsset[assetID]
+		op.orderUTXOs(assetUTXOs, amounts[assetID])
+		selected = append(selected, assetUTXOs...)
+	}
+	return selected, nil
+}
+
+// filterUTXOs returns the UTXOs of [utxos] that are allowed and not
+// excluded, or an error if an allowed UTXO is missing.
+func (op *Op) filterUTXOs(utxos []*avax.UTXO) ([]*avax.UTXO, error) {
+	allowed := make(map[ids.ID]bool, len(op.utxoIDs))
+	for _, utxoID := range op.utxoIDs {
+		allowed[utxoID] = false
+	}
+	excluded := make(map[ids.ID]struct{}, len(op.excludedUTXOIDs))
+	for _, utxoID := range op.excludedUTXOIDs {
+		excluded[utxoID] = struct{}{}
+	}
+
+	selected := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		utxoID := utxo.InputID()
+		if _, ok := excluded[utxoID]; ok {
+			continue
+		}
+		if len(allowed) > 0 {
+			if _, ok := allowed[utxoID]; !ok {
+				continue
+			}
+			allowed[utxoID] = true
+		}
+		selected = append(selected, utxo)
+	}
+	for utxoID, found := range allowed {
+		if !found {
+			return nil, fmt.Errorf("%w (input ID %s)", ErrUTXONotFound, utxoID)
+		}
+	}
+	return selected, nil
+}
+
+// orderUTXOs orders [utxos] in place by the coin selection,
+// to consume [amount].
+func (op *Op) orderUTXOs(utxos []*avax.UTXO, amount uint64) {
+	switch op.coinSelection {
+	case CoinSelectionLargestFirst:
+		sort.SliceStable(utxos, func(i, j int) bool {
+			return utxoAmount(utxos[i]) > utxoAmount(utxos[j])
+		})
+	case CoinSelectionSmallestFirst:
+		sort.SliceStable(utxos, func(i, j int) bool {
+			return utxoAmount(utxos[i]) < utxoAmount(utxos[j])
+		})
+	case CoinSelectionMinimizeInputs:
+		sort.SliceStable(utxos, func(i, j int) bool {
+			return utxoAmount(utxos[i]) > utxoAmount(utxos[j])
+		})
+		// the last UTXO that covers the whole amount is the smallest one
+		cover := -1
+		for i, utxo := range utxos {
+			if utxoAmount(utxo) < amount {
+				break
+			}
+			cover = i
+		}
+		if cover > 0 {
+			utxo := utxos[cover]
+			copy(utxos[1:cover+1], utxos[:cover])
+			utxos[0] = utxo
+		}
+	}
+}
+
+// utxoAmount returns the amount of [utxo], or 0 if it is unknown.
+func utxoAmount(utxo *avax.UTXO) uint64 {
+	out, ok := utxo.Out.(avax.Amounter)
+	if !ok {
+		return 0
+	}
+	return out.Amount()
+}
@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestParseCoinSelection(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		s      string
+		exp    CoinSelection
+		expErr error
+	}{
+		{s: "", exp: CoinSelectionDefault},
+		{s: "largest-first", exp: CoinSelectionLargestFirst},
+		{s: "smallest-first", exp: CoinSelectionSmallestFirst},
+		{s: "minimize-inputs", exp: CoinSelectionMinimizeInputs},
+		{s: "random", expErr: ErrInvalidCoinSelection},
+		{s: "Largest-First", expErr: ErrInvalidCoinSelection},
+	}
+	for i, tv := range tt {
+		cs, err := ParseCoinSelection(tv.s)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%q): unexpected error %v, expected %v", i, tv.s, err, tv.expErr)
+		}
+		if cs != tv.exp {
+			t.Fatalf("#%d(%q): unexpected coin selection %q, expected %q", i, tv.s, cs, tv.exp)
+		}
+	}
+}
+
+func TestParseUTXOID(t *testing.T) {
+	t.Parallel()
+
+	txID := ids.GenerateTestID()
+	tt := []struct {
+		s      string
+		exp    ids.ID
+		expErr error
+	}{
+		{s: fmt.Sprintf("%s:0", txID), exp: (&avax.UTXOID{TxID: txID}).InputID()},
+		{s: fmt.Sprintf("%s:7", txID), exp: (&avax.UTXOID{TxID: txID, OutputIndex: 7}).InputID()},
+		{s: txID.String(), expErr: ErrInvalidUTXOID},
+		{s: fmt.Sprintf("%s:-1", txID), expErr: ErrInvalidUTXOID},
+		{s: fmt.Sprintf("%s:4294967296", txID), expErr: ErrInvalidUTXOID},
+		{s: "invalid:0", expErr: ErrInvalidUTXOID},
+	}
+	for i, tv := range tt {
+		utxoID, err := ParseUTXOID(tv.s)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%q): unexpected error %v, expected %v", i, tv.s, err, tv.expErr)
+		}
+		if utxoID != tv.exp {
+			t.Fatalf("#%d(%q): unexpected UTXO ID %s, expected %s", i, tv.s, utxoID, tv.exp)
+		}
+	}
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	t.Parallel()
+
+	// UTXOs in the order the API returns them
+	amounts := []uint64{30, 100, 10, 50, 70}
+	utxos := make([]*avax.UTXO, len(amounts))
+	for i, amount := range amounts {
+		utxos[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID(), OutputIndex: uint32(i)},
+			Out:    &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	inputID := func(i int) ids.ID { return utxos[i].InputID() }
+
+	tt := []struct {
+		name   string
+		opts   []OpOption
+		amount uint64
+		exp    []uint64
+		expErr error
+	}{
+		{
+			name: "default keeps the API order",
+			exp:  []uint64{30, 100, 10, 50, 70},
+		},
+		{
+			name: "largest first",
+			opts: []OpOption{WithCoinSelection(CoinSelectionLargestFirst)},
+			exp:  []uint64{100, 70, 50, 30, 10},
+		},
+		{
+			name: "smallest first",
+			opts: []OpOption{WithCoinSelection(CoinSelectionSmallestFirst)},
+			exp:  []uint64{10, 30, 50, 70, 100},
+		},
+		{
+			name:   "minimize inputs moves the smallest covering UTXO first",
+			opts:   []OpOption{WithCoinSelection(CoinSelectionMinimizeInputs)},
+			amount: 45,
+			exp:    []uint64{50, 100, 70, 30, 10},
+		},
+		{
+			name:   "minimize inputs with the largest UTXO covering exactly",
+			opts:   []OpOption{WithCoinSelection(CoinSelectionMinimizeInputs)},
+			amount: 100,
+			exp:    []uint64{100, 70, 50, 30, 10},
+		},
+		{
+			name:   "minimize inputs falls back to largest first",
+			opts:   []OpOption{WithCoinSelection(CoinSelectionMinimizeInputs)},
+			amount: 150,
+			exp:    []uint64{100, 70, 50, 30, 10},
+		},
+		{
+			name: "allow list",
+			opts: []OpOption{WithUTXOIDs([]ids.ID{inputID(3), inputID(0)})},
+			exp:  []uint64{30, 50},
+		},
+		{
+			name: "deny list",
+			opts: []OpOption{WithExcludedUTXOIDs([]ids.ID{inputID(1), inputID(4)})},
+			exp:  []uint64{30, 10, 50},
+		},
+		{
+			name: "allowed UTXO excluded by the deny list",
+			opts: []OpOption{
+				WithUTXOIDs([]ids.ID{inputID(1), inputID(2)}),
+				WithExcludedUTXOIDs([]ids.ID{inputID(1)}),
+			},
+			expErr: ErrUTXONotFound,
+		},
+		{
+			name: "allow list sorted by coin selection",
+			opts: []OpOption{
+				WithUTXOIDs([]ids.ID{inputID(0), inputID(1), inputID(2)}),
+				WithCoinSelection(CoinSelectionSmallestFirst),
+			},
+			exp: []uint64{10, 30, 100},
+		},
+		{
+			name:   "allow list with an unknown UTXO",
+			opts:   []OpOption{WithUTXOIDs([]ids.ID{inputID(0), ids.GenerateTestID()})},
+			expErr: ErrUTXONotFound,
+		},
+	}
+	for i, tv := range tt {
+		op := &Op{}
+		op.applyOpts(tv.opts)
+		selected, err := op.selectUTXOs(utxos, tv.amount)
+		if !errors.Is(err, tv.expErr) {
+			t.Fatalf("#%d(%s): unexpected error %v, expected %v", i, tv.name, err, tv.expErr)
+		}
+		if len(selected) != len(tv.exp) {
+			t.Fatalf("#%d(%s): unexpected %d UTXOs, expected %d", i, tv.name, len(selected), len(tv.exp))
+		}
+		for j, utxo := range selected {
+			if amount := utxoAmount(utxo); amount != tv.exp[j] {
+				t.Fatalf("#%d(%s): unexpected UTXO %d amount %d, expected %d", i, tv.name, j, amount, tv.exp[j])
+			}
+		}
+	}
+
+	// the UTXOs of the caller are not reordered
+	for i, utxo := range utxos {
+		if amount := utxoAmount(utxo); amount != amounts[i] {
+			t.Fatalf("unexpected UTXO %d amount %d, expected %d", i, amount, amounts[i])
+		}
+	}
+}
+
+func TestSelectAssetUTXOs(t *testing.T) {
+	t.Parallel()
+
+	stakeAssetID, feeAssetID, otherAssetID := ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()
+	newUTXO := func(assetID ids.ID, amount uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out:    &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+	// a 50 stake UTXO covers the stake, a 8 fee UTXO covers the fee,
+	// but none covers both
+	utxos := []*avax.UTXO{
+		newUTXO(stakeAssetID, 30),
+		newUTXO(feeAssetID, 3),
+		newUTXO(stakeAssetID, 100),
+		newUTXO(otherAssetID, 1000),
+		newUTXO(feeAssetID, 20),
+		newUTXO(stakeAssetID, 50),
+		newUTXO(feeAssetID, 8),
+	}
+
+	op := &Op{}
+	op.applyOpts([]OpOption{
+		WithCoinSelection(CoinSelectionMinimizeInputs),
+		WithExcludedUTXOIDs([]ids.ID{utxos[0].InputID()}),
+	})
+	selected, err := op.selectAssetUTXOs(utxos, map[ids.ID]uint64{stakeAssetID: 45, feeAssetID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[ids.ID][]uint64{
+		stakeAssetID: {50, 100},
+		feeAssetID:   {8, 20, 3},
+	}
+	got := map[ids.ID][]uint64{}
+	for _, utxo := range selected {
+		got[utxo.AssetID()] = append(got[utxo.AssetID()], utxoAmount(utxo))
+	}
+	if len(got) != len(exp) {
+		t.Fatalf("unexpected assets %v, expected %v", got, exp)
+	}
+	for assetID, amounts := range exp {
+		if fmt.Sprint(got[assetID]) != fmt.Sprint(amounts) {
+			t.Fatalf("unexpected %s amounts %v, expected %v", assetID, got[assetID], amounts)
+		}
+	}
+
+	// the allow list spans all the assets
+	op = &Op{}
+	op.applyOpts([]OpOption{WithUTXOIDs([]ids.ID{utxos[2].InputID(), utxos[6].InputID()})})
+	selected, err = op.selectAssetUTXOs(utxos, map[ids.ID]uint64{stakeAssetID: 45, feeAssetID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selected) != 2 {
+		t.Fatalf("unexpected %d UTXOs, expected 2", len(selected))
+	}
+}
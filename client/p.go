@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -33,6 +34,11 @@ var (
 	ErrInsufficientBalanceForGasFee      = errors.New("insufficient balance for gas")
 	ErrInsufficientBalanceForStakeAmount = errors.New("insufficient balance for stake amount")
 	ErrUnexpectedSubnetID                = errors.New("unexpected subnet ID")
+	ErrInvalidThreshold                  = errors.New("invalid threshold")
+	ErrControlKeysNotSortedUnique        = errors.New("control keys not sorted and unique")
+	ErrInsufficientBalance               = errors.New("insufficient balance")
+	ErrNoAtomicUTXOs                     = errors.New("no atomic UTXOs to import")
+	ErrTxNotAccepted                     = errors.New("tx not accepted")
 
 	ErrEmptyValidator              = errors.New("empty validator set")
 	ErrAlreadyValidator            = errors.New("already validator")
@@ -41,17 +47,37 @@ var (
 	ErrInvalidSubnetValidatePeriod = errors.New("invalid subnet validate period")
 	ErrInvalidValidatorData        = errors.New("invalid validator data")
 	ErrValidatorNotFound           = errors.New("validator not found")
+	ErrInvalidDelegatePeriod       = errors.New("invalid delegate period")
+	ErrDelegationCapacityExceeded  = errors.New("delegation capacity exceeded")
 
 	// ref. "vms.platformvm".
 	ErrWrongTxType   = errors.New("wrong transaction type")
 	ErrUnknownOwners = errors.New("unknown owners")
 	ErrCantSign      = errors.New("can't sign")
+
+	ErrInvalidSubnetAuthKeys = errors.New("invalid subnet auth keys")
+	ErrInvalidOwner          = errors.New("invalid owner")
+	ErrInvalidSupply         = errors.New("invalid supply")
+
+	ErrMissingProofOfPossession = errors.New("missing BLS proof of possession")
 )
 
 type P interface {
 	Client() platformvm.Client
 	Checker() internal_platformvm.Checker
 	Balance(ctx context.Context, key key.Key) (uint64, error)
+	// UsedAddresses returns the addresses of [addrs]
+	// that own UTXOs on the P-Chain.
+	UsedAddresses(ctx context.Context, addrs []ids.ShortID) ([]ids.ShortID, error)
+	// Inputs returns the inputs the coin selection of [opts] consumes
+	// to burn [fee] and stake the amount of [opts], without issuing
+	// any transaction.
+	Inputs(
+		ctx context.Context,
+		k key.Key,
+		fee uint64,
+		opts ...OpOption,
+	) (ins []*avax.TransferableInput, err error)
 	CreateSubnet(
 		ctx context.Context,
 		key key.Key,
@@ -65,6 +91,37 @@ type P interface {
 		end time.Time,
 		opts ...OpOption,
 	) (took time.Duration, err error)
+	AddDelegator(
+		ctx context.Context,
+		k key.Key,
+		nodeID ids.NodeID,
+		start time.Time,
+		end time.Time,
+		opts ...OpOption,
+	) (took time.Duration, err error)
+	// AddPermissionlessValidator adds [nodeID] to the permissionless
+	// [subnetID], staking the asset of the subnet. On the primary network,
+	// the BLS key of the node must be set with WithProofOfPossession.
+	AddPermissionlessValidator(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		start time.Time,
+		end time.Time,
+		opts ...OpOption,
+	) (took time.Duration, err error)
+	// AddPermissionlessDelegator delegates the asset of the permissionless
+	// [subnetID] to [nodeID].
+	AddPermissionlessDelegator(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		nodeID ids.NodeID,
+		start time.Time,
+		end time.Time,
+		opts ...OpOption,
+	) (took time.Duration, err error)
 	AddSubnetValidator(
 		ctx context.Context,
 		k key.Key,
@@ -91,11 +148,44 @@ type P interface {
 		vmGenesis []byte,
 		opts ...OpOption,
 	) (blkChainID ids.ID, took time.Duration, err error)
+	// TransformSubnet converts [subnetID] to a permissionless subnet
+	// staked with the asset of [cfg], burning the rewards to mint.
+	TransformSubnet(
+		ctx context.Context,
+		k key.Key,
+		subnetID ids.ID,
+		cfg *TransformSubnetConfig,
+		opts ...OpOption,
+	) (took time.Duration, err error)
+	// GetValidator returns the current or pending validator record of
+	// [nodeID] on [rsubnetID], or ErrValidatorNotFound.
 	GetValidator(
 		ctx context.Context,
 		rsubnetID ids.ID,
 		nodeID ids.NodeID,
-	) (start time.Time, end time.Time, err error)
+	) (vdr *Validator, err error)
+	// GetSubnet returns the control keys, validators and blockchains
+	// of [subnetID].
+	GetSubnet(ctx context.Context, subnetID ids.ID) (*Subnet, error)
+	// Export exports [amount] AVAX to the key on [chainID].
+	Export(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		amount uint64,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+	// Import imports all AVAX (or the asset set with WithAssetID)
+	// exported to the key from [chainID].
+	Import(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+	// Issue issues a fully signed transaction (e.g., assembled
+	// from a PartialTx) and waits for it to be committed.
+	Issue(ctx context.Context, pTx *txs.Tx) (txID ids.ID, took time.Duration, err error)
 }
 
 type p struct {
@@ -113,12 +203,74 @@ type p struct {
 func (pc *p) Client() platformvm.Client            { return pc.cli }
 func (pc *p) Checker() internal_platformvm.Checker { return pc.checker }
 
+// Balance returns the AVAX held by [key], including the locked AVAX, with
+// each UTXO counted once even if several of its addresses own it.
 func (pc *p) Balance(ctx context.Context, key key.Key) (uint64, error) {
-	pb, err := pc.cli.GetBalance(ctx, key.Addresses())
+	utxos, err := pc.utxos(ctx, key, ids.Empty)
 	if err != nil {
 		return 0, err
 	}
-	return uint64(pb.Balance), nil
+	balance := uint64(0)
+	for _, utxo := range utxos {
+		if utxo.AssetID() != pc.assetID {
+			continue
+		}
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok {
+			continue
+		}
+		balance, err = math.Add64(balance, out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return balance, nil
+}
+
+func (pc *p) UsedAddresses(ctx context.Context, addrs []ids.ShortID) ([]ids.ShortID, error) {
+	utxos, err := internal_avax.GetAllUTXOs(ctx, addrs, func(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		limit uint32,
+		startAddr ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		return pc.cli.GetUTXOs(ctx, addrs, limit, startAddr, startUTXOID)
+	}, txs.Codec)
+	if err != nil {
+		return nil, err
+	}
+	owned := map[ids.ShortID]struct{}{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.Addressable)
+		if !ok {
+			continue
+		}
+		for _, addr := range out.Addresses() {
+			saddr, err := ids.ToShortID(addr)
+			if err != nil {
+				return nil, err
+			}
+			owned[saddr] = struct{}{}
+		}
+	}
+	used := []ids.ShortID{}
+	for _, addr := range addrs {
+		if _, ok := owned[addr]; ok {
+			used = append(used, addr)
+		}
+	}
+	return used, nil
+}
+
+func (pc *p) Inputs(
+	ctx context.Context,
+	k key.Key,
+	fee uint64,
+	opts ...OpOption,
+) (ins []*avax.TransferableInput, err error) {
+	ins, _, _, _, err = pc.stake(ctx, k, fee, opts...)
+	return ins, err
 }
 
 // ref. "platformvm.VM.newCreateSubnetTx".
@@ -136,12 +288,31 @@ func (pc *p) CreateSubnet(
 	}
 	createSubnetTxFee := uint64(fi.CreateSubnetTxFee)
 
+	if len(ret.controlKeys) == 0 {
+		ret.controlKeys = []ids.ShortID{k.Addresses()[0]}
+		zap.L().Warn("control keys not set, default to self",
+			zap.String("controlKey", ret.controlKeys[0].String()),
+		)
+	}
+	if ret.threshold == 0 {
+		ret.threshold = 1
+		zap.L().Warn("threshold not set, default to 1")
+	}
+	if !ids.IsSortedAndUniqueShortIDs(ret.controlKeys) {
+		return ids.Empty, 0, ErrControlKeysNotSortedUnique
+	}
+	if ret.threshold > uint32(len(ret.controlKeys)) {
+		return ids.Empty, 0, fmt.Errorf("%w (threshold %d expected <=%d)", ErrInvalidThreshold, ret.threshold, len(ret.controlKeys))
+	}
+
 	zap.L().Info("creating subnet",
 		zap.Bool("dryMode", ret.dryMode),
 		zap.String("assetId", pc.assetID.String()),
 		zap.Uint64("createSubnetTxFee", createSubnetTxFee),
+		zap.Int("controlKeys", len(ret.controlKeys)),
+		zap.Uint32("threshold", ret.threshold),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createSubnetTxFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createSubnetTxFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
 	if err != nil {
 		return ids.Empty, 0, err
 	}
@@ -155,16 +326,19 @@ func (pc *p) CreateSubnet(
 		}},
 		Owner: &secp256k1fx.OutputOwners{
 			// [threshold] of [ownerAddrs] needed to manage this subnet
-			Threshold: 1,
+			Threshold: ret.threshold,
 
-			// address to send change to, if there is any,
 			// control addresses for the new subnet
-			Addrs: []ids.ShortID{k.Addresses()[0]},
+			Addrs: ret.controlKeys,
 		},
 	}
 	pTx := &txs.Tx{
 		Unsigned: utx,
 	}
+	if ret.partialTx != nil {
+		// subnet ID is only known once all signatures are collected
+		return ids.Empty, 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
 	if err := k.Sign(pTx, signers); err != nil {
 		return ids.Empty, 0, err
 	}
@@ -178,7 +352,7 @@ func (pc *p) CreateSubnet(
 	// subnet tx ID is the subnet ID based on ins/outs
 	subnetID = pTx.ID()
 	if ret.dryMode {
-		return subnetID, 0, nil
+		return subnetID, 0, pc.dryRun(pTx, ret)
 	}
 
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
@@ -193,7 +367,92 @@ func (pc *p) CreateSubnet(
 	return txID, took, err
 }
 
-func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.NodeID) (start time.Time, end time.Time, err error) {
+const (
+	// ref. "executor.MaxValidatorWeightFactor"
+	maxValidatorWeightFactor = 5
+	// ref. "genesis.StakingConfig.MaxValidatorStake"
+	maxValidatorStake = 3 * units.MegaAvax
+)
+
+// ValidatorState is the state of a node in the validator set of a subnet.
+type ValidatorState uint8
+
+const (
+	// ValidatorStateNone is the state of a node that is not a validator.
+	ValidatorStateNone ValidatorState = iota
+	// ValidatorStatePending is the state of a validator that was added
+	// but has not started validating yet.
+	ValidatorStatePending
+	// ValidatorStateCurrent is the state of a validating node.
+	ValidatorStateCurrent
+)
+
+func (s ValidatorState) String() string {
+	switch s {
+	case ValidatorStatePending:
+		return "pending"
+	case ValidatorStateCurrent:
+		return "current"
+	default:
+		return "none"
+	}
+}
+
+// Validator is the validator record of a node on a subnet.
+type Validator struct {
+	// TxID is the ID of the transaction that added the validator.
+	TxID   ids.ID
+	NodeID ids.NodeID
+	State  ValidatorState
+	Start  time.Time
+	End    time.Time
+	// Weight is the stake amount of a primary network validator,
+	// or the validate weight of a subnet validator.
+	Weight uint64
+	// Delegated is the total amount delegated to the validator.
+	Delegated     uint64
+	DelegationFee float32
+}
+
+// DelegationCapacity returns the amount that can still be delegated
+// to a primary network validator.
+func (v *Validator) DelegationCapacity() uint64 {
+	maxWeight, err := math.Mul64(v.Weight, maxValidatorWeightFactor)
+	if err != nil {
+		maxWeight = maxValidatorStake
+	}
+	maxWeight = math.Min64(maxWeight, maxValidatorStake)
+	total := v.Weight + v.Delegated
+	if total >= maxWeight {
+		return 0
+	}
+	return maxWeight - total
+}
+
+func newValidator(v platformvm.ClientPermissionlessValidator) *Validator {
+	vdr := &Validator{
+		TxID:          v.TxID,
+		NodeID:        v.NodeID,
+		State:         ValidatorStateCurrent,
+		Start:         time.Unix(int64(v.StartTime), 0),
+		End:           time.Unix(int64(v.EndTime), 0),
+		DelegationFee: v.DelegationFee,
+	}
+	switch {
+	case v.StakeAmount != nil:
+		vdr.Weight = *v.StakeAmount
+	case v.Weight != nil:
+		vdr.Weight = *v.Weight
+	}
+	for _, d := range v.Delegators {
+		if d.StakeAmount != nil {
+			vdr.Delegated += *d.StakeAmount
+		}
+	}
+	return vdr
+}
+
+func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.NodeID) (*Validator, error) {
 	// If no [rsubnetID] is provided, just use the PrimaryNetworkID value.
 	subnetID := constants.PrimaryNetworkID
 	if rsubnetID != ids.Empty {
@@ -203,22 +462,26 @@ func (pc *p) GetValidator(ctx context.Context, rsubnetID ids.ID, nodeID ids.Node
 	// Find validator data associated with [nodeID]
 	vs, err := pc.Client().GetCurrentValidators(ctx, subnetID, []ids.NodeID{nodeID})
 	if err != nil {
-		return time.Time{}, time.Time{}, err
+		return nil, err
 	}
 
-	// If the validator is not found, it will return a string record indicating
-	// that it was "unable to get mainnet validator record".
-	if len(vs) < 1 {
-		return time.Time{}, time.Time{}, ErrValidatorNotFound
-	}
 	for _, v := range vs {
 		if v.NodeID == nodeID {
-			return time.Unix(int64(v.StartTime), 0), time.Unix(int64(v.EndTime), 0), nil
+			return newValidator(v), nil
+		}
+	}
+
+	// A validator that was just added is pending until its start time.
+	pvs, err := pc.pendingValidators(ctx, subnetID, []ids.NodeID{nodeID})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range pvs {
+		if v.NodeID == nodeID {
+			return v, nil
 		}
 	}
-	// This should never happen if the length of [vs] > 1, however,
-	// we defend against it in case.
-	return time.Time{}, time.Time{}, ErrValidatorNotFound
+	return nil, ErrValidatorNotFound
 }
 
 // ref. "platformvm.VM.newAddSubnetValidatorTx".
@@ -244,12 +507,14 @@ func (pc *p) AddSubnetValidator(
 		return 0, ErrEmptyID
 	}
 
-	_, _, err = pc.GetValidator(ctx, subnetID, nodeID)
-	if !errors.Is(err, ErrValidatorNotFound) {
-		return 0, ErrAlreadySubnetValidator
+	svdr, err := pc.GetValidator(ctx, subnetID, nodeID)
+	if err == nil {
+		return 0, fmt.Errorf("%w (%s validator)", ErrAlreadySubnetValidator, svdr.State)
+	} else if !errors.Is(err, ErrValidatorNotFound) {
+		return 0, fmt.Errorf("%w: unable to get subnet validator record", err)
 	}
 
-	validateStart, validateEnd, err := pc.GetValidator(ctx, ids.ID{}, nodeID)
+	vdr, err := pc.GetValidator(ctx, ids.ID{}, nodeID)
 	if errors.Is(err, ErrValidatorNotFound) {
 		return 0, ErrNotValidatingPrimaryNetwork
 	} else if err != nil {
@@ -258,11 +523,11 @@ func (pc *p) AddSubnetValidator(
 	// make sure the range is within staker validation start/end on the primary network
 	// TODO: official wallet client should define the error value for such case
 	// currently just returns "staking too short"
-	if start.Before(validateStart) {
-		return 0, fmt.Errorf("%w (validate start %v expected >%v)", ErrInvalidSubnetValidatePeriod, start, validateStart)
+	if start.Before(vdr.Start) {
+		return 0, fmt.Errorf("%w (validate start %v expected >%v)", ErrInvalidSubnetValidatePeriod, start, vdr.Start)
 	}
-	if end.After(validateEnd) {
-		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, validateEnd)
+	if end.After(vdr.End) {
+		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, vdr.End)
 	}
 
 	fi, err := pc.info.GetTxFee(ctx)
@@ -278,11 +543,11 @@ func (pc *p) AddSubnetValidator(
 		zap.Time("end", end),
 		zap.Uint64("weight", weight),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, txFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, txFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
 	if err != nil {
 		return 0, err
 	}
-	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret)
 	if err != nil {
 		return 0, err
 	}
@@ -309,6 +574,9 @@ func (pc *p) AddSubnetValidator(
 	pTx := &txs.Tx{
 		Unsigned: utx,
 	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
 	if err := k.Sign(pTx, signers); err != nil {
 		return 0, err
 	}
@@ -318,6 +586,9 @@ func (pc *p) AddSubnetValidator(
 	}); err != nil {
 		return 0, err
 	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
 	if err != nil {
 		return 0, fmt.Errorf("failed to issue tx: %w", err)
@@ -346,7 +617,7 @@ func (pc *p) RemoveSubnetValidator(
 		return 0, ErrEmptyID
 	}
 
-	_, validateEnd, err := pc.GetValidator(ctx, subnetID, nodeID)
+	vdr, err := pc.GetValidator(ctx, subnetID, nodeID)
 	if errors.Is(err, ErrValidatorNotFound) {
 		return 0, ErrValidatorNotFound
 	} else if err != nil {
@@ -355,8 +626,8 @@ func (pc *p) RemoveSubnetValidator(
 	// make sure the range is within staker validation start/end on the subnet
 	now := time.Now()
 	// We don't check [validateStart] because we can remove pending validators.
-	if now.After(validateEnd) {
-		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, now, validateEnd)
+	if now.After(vdr.End) {
+		return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, now, vdr.End)
 	}
 
 	fi, err := pc.info.GetTxFee(ctx)
@@ -369,11 +640,11 @@ func (pc *p) RemoveSubnetValidator(
 		zap.String("subnetId", subnetID.String()),
 		zap.Uint64("txFee", txFee),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, txFee)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, txFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
 	if err != nil {
 		return 0, err
 	}
-	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID)
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret)
 	if err != nil {
 		return 0, err
 	}
@@ -393,6 +664,9 @@ func (pc *p) RemoveSubnetValidator(
 	pTx := &txs.Tx{
 		Unsigned: utx,
 	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
 	if err := k.Sign(pTx, signers); err != nil {
 		return 0, err
 	}
@@ -402,6 +676,9 @@ func (pc *p) RemoveSubnetValidator(
 	}); err != nil {
 		return 0, err
 	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
 	if err != nil {
 		return 0, fmt.Errorf("failed to issue tx: %w", err)
@@ -426,9 +703,9 @@ func (pc *p) AddValidator(
 		return 0, ErrEmptyID
 	}
 
-	_, _, err = pc.GetValidator(ctx, ids.ID{}, nodeID)
+	vdr, err := pc.GetValidator(ctx, ids.ID{}, nodeID)
 	if err == nil {
-		return 0, ErrAlreadyValidator
+		return 0, fmt.Errorf("%w (%s validator)", ErrAlreadyValidator, vdr.State)
 	} else if !errors.Is(err, ErrValidatorNotFound) {
 		return 0, err
 	}
@@ -448,25 +725,28 @@ func (pc *p) AddValidator(
 			zap.Uint64("stakeAmount", ret.stakeAmt),
 		)
 	}
-	if ret.rewardAddr == ids.ShortEmpty {
-		ret.rewardAddr = k.Addresses()[0]
-		zap.L().Warn("reward address not set, default to self",
-			zap.String("rewardAddress", ret.rewardAddr.String()),
+	if ret.rewardOwner == nil {
+		ret.rewardOwner = selfOwner(k)
+		zap.L().Warn("reward owner not set, default to self",
+			zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
 		)
 	}
-	if ret.changeAddr == ids.ShortEmpty {
-		ret.changeAddr = k.Addresses()[0]
-		zap.L().Warn("change address not set",
-			zap.String("changeAddress", ret.changeAddr.String()),
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+		zap.L().Warn("change owner not set",
+			zap.String("changeOwner", formatOwner(ret.changeOwner)),
 		)
 	}
+	if err := VerifyOwner(ret.rewardOwner); err != nil {
+		return 0, fmt.Errorf("%w: reward owner", err)
+	}
 
 	zap.L().Info("adding validator",
 		zap.Time("start", start),
 		zap.Time("end", end),
 		zap.Uint64("stakeAmount", ret.stakeAmt),
-		zap.String("rewardAddress", ret.rewardAddr.String()),
-		zap.String("changeAddress", ret.changeAddr.String()),
+		zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		zap.String("changeOwner", formatOwner(ret.changeOwner)),
 	)
 
 	// ref. https://docs.avax.network/learn/platform-overview/transaction-fees/#fee-schedule
@@ -477,9 +757,9 @@ func (pc *p) AddValidator(
 		k,
 		addStakerTxFee,
 		WithStakeAmount(ret.stakeAmt),
-		WithRewardAddress(ret.rewardAddr),
 		WithRewardShares(ret.rewardShares),
-		WithChangeAddress(ret.changeAddr),
+		WithChangeOwner(ret.changeOwner),
+		withSelectionOf(ret),
 	)
 	if err != nil {
 		return 0, err
@@ -498,17 +778,16 @@ func (pc *p) AddValidator(
 			End:    uint64(end.Unix()),
 			Wght:   ret.stakeAmt,
 		},
-		StakeOuts: stakedOuts,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{ret.rewardAddr},
-		},
+		StakeOuts:        stakedOuts,
+		RewardsOwner:     ret.rewardOwner,
 		DelegationShares: ret.rewardShares,
 	}
 	pTx := &txs.Tx{
 		Unsigned: utx,
 	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
 	if err := k.Sign(pTx, signers); err != nil {
 		return 0, err
 	}
@@ -519,6 +798,9 @@ func (pc *p) AddValidator(
 	}); err != nil {
 		return 0, err
 	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
 	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
 	if err != nil {
 		return 0, fmt.Errorf("failed to issue tx: %w", err)
@@ -527,134 +809,1040 @@ func (pc *p) AddValidator(
 	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
 }
 
-// ref. "platformvm.VM.newCreateChainTx".
-func (pc *p) CreateBlockchain(
+// ref. "platformvm.VM.newAddDelegatorTx".
+func (pc *p) AddDelegator(
 	ctx context.Context,
 	k key.Key,
-	subnetID ids.ID,
-	chainName string,
-	vmID ids.ID,
-	vmGenesis []byte,
+	nodeID ids.NodeID,
+	start time.Time,
+	end time.Time,
 	opts ...OpOption,
-) (blkChainID ids.ID, took time.Duration, err error) {
+) (took time.Duration, err error) {
 	ret := &Op{}
 	ret.applyOpts(opts)
 
-	if subnetID == ids.Empty {
-		return ids.Empty, 0, ErrEmptyID
+	if nodeID == ids.EmptyNodeID {
+		return 0, ErrEmptyID
 	}
-	if vmID == ids.Empty {
-		return ids.Empty, 0, ErrEmptyID
+
+	vdr, err := pc.GetValidator(ctx, ids.ID{}, nodeID)
+	if errors.Is(err, ErrValidatorNotFound) {
+		return 0, ErrNotValidatingPrimaryNetwork
+	} else if err != nil {
+		return 0, fmt.Errorf("%w: unable to get primary network validator record", err)
+	}
+	// make sure the range is within staker validation start/end on the primary network
+	if start.Before(vdr.Start) {
+		return 0, fmt.Errorf("%w (delegate start %v expected >%v)", ErrInvalidDelegatePeriod, start, vdr.Start)
+	}
+	if end.After(vdr.End) {
+		return 0, fmt.Errorf("%w (delegate end %v expected <%v)", ErrInvalidDelegatePeriod, end, vdr.End)
+	}
+
+	// ref. https://docs.avax.network/learn/platform-overview/staking/#staking-parameters-on-avalanche
+	if ret.stakeAmt == 0 {
+		switch pc.networkName {
+		case constants.MainnetName,
+			constants.LocalName:
+			ret.stakeAmt = 25 * units.Avax
+		case constants.FujiName:
+			ret.stakeAmt = 1 * units.Avax
+		}
+		zap.L().Info("stake amount not set, default to network setting",
+			zap.String("networkName", pc.networkName),
+			zap.Uint64("stakeAmount", ret.stakeAmt),
+		)
+	}
+	if capacity := vdr.DelegationCapacity(); ret.stakeAmt > capacity {
+		return 0, fmt.Errorf("%w (stake amount %d expected <=%d)", ErrDelegationCapacityExceeded, ret.stakeAmt, capacity)
+	}
+	if ret.rewardOwner == nil {
+		ret.rewardOwner = selfOwner(k)
+		zap.L().Warn("reward owner not set, default to self",
+			zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		)
+	}
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+		zap.L().Warn("change owner not set",
+			zap.String("changeOwner", formatOwner(ret.changeOwner)),
+		)
+	}
+	if err := VerifyOwner(ret.rewardOwner); err != nil {
+		return 0, fmt.Errorf("%w: reward owner", err)
 	}
 
 	fi, err := pc.info.GetTxFee(ctx)
 	if err != nil {
-		return ids.Empty, 0, err
+		return 0, err
 	}
-	createBlkChainTxFee := uint64(fi.CreateBlockchainTxFee)
+	addDelegatorTxFee := uint64(fi.AddPrimaryNetworkDelegatorFee)
 
-	now := time.Now()
-	zap.L().Info("creating blockchain",
-		zap.String("subnetId", subnetID.String()),
-		zap.String("chainName", chainName),
-		zap.String("vmId", vmID.String()),
-		zap.Uint64("createBlockchainTxFee", createBlkChainTxFee),
+	zap.L().Info("adding delegator",
+		zap.String("nodeId", nodeID.String()),
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Uint64("stakeAmount", ret.stakeAmt),
+		zap.Uint64("addDelegatorTxFee", addDelegatorTxFee),
+		zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		zap.String("changeOwner", formatOwner(ret.changeOwner)),
+	)
+
+	ins, returnedOuts, stakedOuts, signers, err := pc.stake(
+		ctx,
+		k,
+		addDelegatorTxFee,
+		WithStakeAmount(ret.stakeAmt),
+		WithChangeOwner(ret.changeOwner),
+		withSelectionOf(ret),
 	)
-	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createBlkChainTxFee)
-	if err != nil {
-		return ids.Empty, 0, err
-	}
-	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID)
 	if err != nil {
-		return ids.Empty, 0, err
+		return 0, err
 	}
-	signers = append(signers, subnetSigners)
 
-	utx := &txs.CreateChainTx{
+	utx := &txs.AddDelegatorTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    pc.networkID,
 			BlockchainID: pc.pChainID,
 			Ins:          ins,
 			Outs:         returnedOuts,
 		}},
-		SubnetID:    subnetID,
-		ChainName:   chainName,
-		VMID:        vmID,
-		FxIDs:       nil,
-		GenesisData: vmGenesis,
-		SubnetAuth:  subnetAuth,
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(start.Unix()),
+			End:    uint64(end.Unix()),
+			Wght:   ret.stakeAmt,
+		},
+		StakeOuts:              stakedOuts,
+		DelegationRewardsOwner: ret.rewardOwner,
 	}
 	pTx := &txs.Tx{
 		Unsigned: utx,
 	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
 	if err := k.Sign(pTx, signers); err != nil {
-		return ids.Empty, 0, err
+		return 0, err
 	}
 	if err := utx.SyntacticVerify(&snow.Context{
-		NetworkID: pc.networkID,
-		ChainID:   pc.pChainID,
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
 	}); err != nil {
-		return ids.Empty, 0, err
+		return 0, err
 	}
-	blkChainID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
-	if err != nil {
-		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
 	}
-
-	took = time.Since(now)
-	if ret.poll {
-		var bTook time.Duration
-		bTook, err = pc.checker.PollBlockchain(
-			ctx,
-			internal_platformvm.WithSubnetID(subnetID),
-			internal_platformvm.WithBlockchainID(blkChainID),
-			internal_platformvm.WithBlockchainStatus(pstatus.Validating),
-			internal_platformvm.WithCheckBlockchainBootstrapped(pc.info),
-		)
-		took += bTook
+	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
 	}
-	return blkChainID, took, err
-}
 
-type Op struct {
-	stakeAmt     uint64
-	rewardShares uint32
-	rewardAddr   ids.ShortID
-	changeAddr   ids.ShortID
-
-	dryMode bool
-	poll    bool
+	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
 }
 
-type OpOption func(*Op)
+// ref. "platformvm.VM.newAddPermissionlessValidatorTx".
+func (pc *p) AddPermissionlessValidator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	start time.Time,
+	end time.Time,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
 
-func (op *Op) applyOpts(opts []OpOption) {
-	for _, opt := range opts {
-		opt(op)
+	if nodeID == ids.EmptyNodeID {
+		return 0, ErrEmptyID
 	}
-}
-
-func WithStakeAmount(v uint64) OpOption {
-	return func(op *Op) {
-		op.stakeAmt = v
+	if ret.stakeAmt == 0 {
+		return 0, fmt.Errorf("%w: zero stake amount", ErrInvalidValidatorData)
 	}
-}
-
-func WithRewardShares(v uint32) OpOption {
-	return func(op *Op) {
-		op.rewardShares = v
+	primary := subnetID == constants.PrimaryNetworkID
+	if primary && ret.proofOfPossession == nil {
+		return 0, ErrMissingProofOfPossession
+	}
+
+	vdr, err := pc.GetValidator(ctx, subnetID, nodeID)
+	switch {
+	case err == nil && primary:
+		return 0, fmt.Errorf("%w (%s validator)", ErrAlreadyValidator, vdr.State)
+	case err == nil:
+		return 0, fmt.Errorf("%w (%s validator)", ErrAlreadySubnetValidator, vdr.State)
+	case !errors.Is(err, ErrValidatorNotFound):
+		return 0, err
+	}
+	if !primary {
+		// make sure the range is within staker validation start/end on the primary network
+		primaryVdr, err := pc.GetValidator(ctx, ids.Empty, nodeID)
+		if errors.Is(err, ErrValidatorNotFound) {
+			return 0, ErrNotValidatingPrimaryNetwork
+		} else if err != nil {
+			return 0, fmt.Errorf("%w: unable to get primary network validator record", err)
+		}
+		if start.Before(primaryVdr.Start) {
+			return 0, fmt.Errorf("%w (validate start %v expected >%v)", ErrInvalidSubnetValidatePeriod, start, primaryVdr.Start)
+		}
+		if end.After(primaryVdr.End) {
+			return 0, fmt.Errorf("%w (validate end %v expected <%v)", ErrInvalidSubnetValidatePeriod, end, primaryVdr.End)
+		}
+	}
+
+	if primary {
+		ret.stakeAssetID = pc.assetID
+	} else if ret.stakeAssetID == ids.Empty {
+		ret.stakeAssetID, err = pc.cli.GetStakingAssetID(ctx, subnetID)
+		if err != nil {
+			return 0, fmt.Errorf("%w: unable to get staking asset of subnet %s", err, subnetID)
+		}
+	}
+	if ret.rewardOwner == nil {
+		ret.rewardOwner = selfOwner(k)
+		zap.L().Warn("reward owner not set, default to self",
+			zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		)
+	}
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+		zap.L().Warn("change owner not set",
+			zap.String("changeOwner", formatOwner(ret.changeOwner)),
+		)
+	}
+	if err := VerifyOwner(ret.rewardOwner); err != nil {
+		return 0, fmt.Errorf("%w: reward owner", err)
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return 0, err
+	}
+	addValidatorTxFee := uint64(fi.AddSubnetValidatorFee)
+	if primary {
+		addValidatorTxFee = uint64(fi.AddPrimaryNetworkValidatorFee)
+	}
+
+	zap.L().Info("adding permissionless validator",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("nodeId", nodeID.String()),
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Uint64("stakeAmount", ret.stakeAmt),
+		zap.String("stakeAssetId", ret.stakeAssetID.String()),
+		zap.Uint64("addValidatorTxFee", addValidatorTxFee),
+		zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		zap.String("changeOwner", formatOwner(ret.changeOwner)),
+	)
+
+	ins, returnedOuts, stakedOuts, signers, err := pc.stake(
+		ctx,
+		k,
+		addValidatorTxFee,
+		WithStakeAmount(ret.stakeAmt),
+		WithStakeAssetID(ret.stakeAssetID),
+		WithChangeOwner(ret.changeOwner),
+		withSelectionOf(ret),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	utx := &txs.AddPermissionlessValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(start.Unix()),
+			End:    uint64(end.Unix()),
+			Wght:   ret.stakeAmt,
+		},
+		Subnet: subnetID,
+		// only primary network validators register a BLS key
+		Signer:                &signer.Empty{},
+		StakeOuts:             stakedOuts,
+		ValidatorRewardsOwner: ret.rewardOwner,
+		DelegatorRewardsOwner: ret.rewardOwner,
+		DelegationShares:      ret.rewardShares,
+	}
+	if primary {
+		utx.Signer = ret.proofOfPossession
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return 0, err
+	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
+	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+}
+
+// ref. "platformvm.VM.newAddPermissionlessDelegatorTx".
+func (pc *p) AddPermissionlessDelegator(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	start time.Time,
+	end time.Time,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty || nodeID == ids.EmptyNodeID {
+		return 0, ErrEmptyID
+	}
+	if ret.stakeAmt == 0 {
+		return 0, fmt.Errorf("%w: zero stake amount", ErrInvalidValidatorData)
+	}
+
+	vdr, err := pc.GetValidator(ctx, subnetID, nodeID)
+	if errors.Is(err, ErrValidatorNotFound) {
+		return 0, fmt.Errorf("%w (%s not validating subnet %s)", ErrValidatorNotFound, nodeID, subnetID)
+	} else if err != nil {
+		return 0, fmt.Errorf("%w: unable to get subnet validator record", err)
+	}
+	// make sure the range is within staker validation start/end on the subnet
+	if start.Before(vdr.Start) {
+		return 0, fmt.Errorf("%w (delegate start %v expected >%v)", ErrInvalidDelegatePeriod, start, vdr.Start)
+	}
+	if end.After(vdr.End) {
+		return 0, fmt.Errorf("%w (delegate end %v expected <%v)", ErrInvalidDelegatePeriod, end, vdr.End)
+	}
+
+	if ret.stakeAssetID == ids.Empty {
+		ret.stakeAssetID, err = pc.cli.GetStakingAssetID(ctx, subnetID)
+		if err != nil {
+			return 0, fmt.Errorf("%w: unable to get staking asset of subnet %s", err, subnetID)
+		}
+	}
+	if ret.rewardOwner == nil {
+		ret.rewardOwner = selfOwner(k)
+		zap.L().Warn("reward owner not set, default to self",
+			zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		)
+	}
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+		zap.L().Warn("change owner not set",
+			zap.String("changeOwner", formatOwner(ret.changeOwner)),
+		)
+	}
+	if err := VerifyOwner(ret.rewardOwner); err != nil {
+		return 0, fmt.Errorf("%w: reward owner", err)
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return 0, err
+	}
+	addDelegatorTxFee := uint64(fi.AddSubnetDelegatorFee)
+
+	zap.L().Info("adding permissionless delegator",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("nodeId", nodeID.String()),
+		zap.Time("start", start),
+		zap.Time("end", end),
+		zap.Uint64("stakeAmount", ret.stakeAmt),
+		zap.String("stakeAssetId", ret.stakeAssetID.String()),
+		zap.Uint64("addDelegatorTxFee", addDelegatorTxFee),
+		zap.String("rewardOwner", formatOwner(ret.rewardOwner)),
+		zap.String("changeOwner", formatOwner(ret.changeOwner)),
+	)
+
+	ins, returnedOuts, stakedOuts, signers, err := pc.stake(
+		ctx,
+		k,
+		addDelegatorTxFee,
+		WithStakeAmount(ret.stakeAmt),
+		WithStakeAssetID(ret.stakeAssetID),
+		WithChangeOwner(ret.changeOwner),
+		withSelectionOf(ret),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	utx := &txs.AddPermissionlessDelegatorTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(start.Unix()),
+			End:    uint64(end.Unix()),
+			Wght:   ret.stakeAmt,
+		},
+		Subnet:                 subnetID,
+		StakeOuts:              stakedOuts,
+		DelegationRewardsOwner: ret.rewardOwner,
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return 0, err
+	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
+	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+}
+
+// ref. "platformvm.VM.newCreateChainTx".
+func (pc *p) CreateBlockchain(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	chainName string,
+	vmID ids.ID,
+	vmGenesis []byte,
+	opts ...OpOption,
+) (blkChainID ids.ID, took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty {
+		return ids.Empty, 0, ErrEmptyID
+	}
+	if vmID == ids.Empty {
+		return ids.Empty, 0, ErrEmptyID
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	createBlkChainTxFee := uint64(fi.CreateBlockchainTxFee)
+
+	now := time.Now()
+	zap.L().Info("creating blockchain",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("chainName", chainName),
+		zap.String("vmId", vmID.String()),
+		zap.Uint64("createBlockchainTxFee", createBlkChainTxFee),
+	)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, createBlkChainTxFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	signers = append(signers, subnetSigners)
+
+	utx := &txs.CreateChainTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		SubnetID:    subnetID,
+		ChainName:   chainName,
+		VMID:        vmID,
+		FxIDs:       nil,
+		GenesisData: vmGenesis,
+		SubnetAuth:  subnetAuth,
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if ret.partialTx != nil {
+		// blockchain ID is only known once all signatures are collected
+		return ids.Empty, 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return ids.Empty, 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID: pc.networkID,
+		ChainID:   pc.pChainID,
+	}); err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.dryMode {
+		// blockchain ID is the ID of the tx that creates it
+		return pTx.ID(), 0, pc.dryRun(pTx, ret)
+	}
+	blkChainID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	took = time.Since(now)
+	if ret.poll {
+		var bTook time.Duration
+		bTook, err = pc.checker.PollBlockchain(
+			ctx,
+			internal_platformvm.WithSubnetID(subnetID),
+			internal_platformvm.WithBlockchainID(blkChainID),
+			internal_platformvm.WithBlockchainStatus(pstatus.Validating),
+			internal_platformvm.WithCheckBlockchainBootstrapped(pc.info),
+		)
+		took += bTook
+	}
+	return blkChainID, took, err
+}
+
+// TransformSubnetConfig are the staking and reward parameters
+// of a permissionless subnet. See "txs.TransformSubnetTx".
+type TransformSubnetConfig struct {
+	AssetID       ids.ID
+	InitialSupply uint64
+	MaximumSupply uint64
+
+	MinConsumptionRate uint64
+	MaxConsumptionRate uint64
+
+	MinValidatorStake uint64
+	MaxValidatorStake uint64
+	MinStakeDuration  time.Duration
+	MaxStakeDuration  time.Duration
+
+	MinDelegationFee         uint32
+	MinDelegatorStake        uint64
+	MaxValidatorWeightFactor byte
+	UptimeRequirement        uint32
+}
+
+// RewardAmount returns the amount of the asset that is burned
+// by the transformation, to be minted as staking rewards.
+func (cfg *TransformSubnetConfig) RewardAmount() uint64 {
+	return cfg.MaximumSupply - cfg.InitialSupply
+}
+
+// ref. "platformvm.VM.newTransformSubnetTx".
+func (pc *p) TransformSubnet(
+	ctx context.Context,
+	k key.Key,
+	subnetID ids.ID,
+	cfg *TransformSubnetConfig,
+	opts ...OpOption,
+) (took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	if subnetID == ids.Empty {
+		return 0, ErrEmptyID
+	}
+	if cfg.InitialSupply > cfg.MaximumSupply {
+		return 0, fmt.Errorf("%w (initial supply %d expected <=%d)", ErrInvalidSupply, cfg.InitialSupply, cfg.MaximumSupply)
+	}
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return 0, err
+	}
+	transformSubnetTxFee := uint64(fi.TransformSubnetTxFee)
+
+	zap.L().Info("transforming subnet",
+		zap.String("subnetId", subnetID.String()),
+		zap.String("assetId", cfg.AssetID.String()),
+		zap.Uint64("rewardAmount", cfg.RewardAmount()),
+		zap.Uint64("transformSubnetTxFee", transformSubnetTxFee),
+	)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, transformSubnetTxFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
+	if err != nil {
+		return 0, err
+	}
+	if cfg.RewardAmount() > 0 {
+		assetIns, assetOuts, assetSigners, err := pc.burn(ctx, k, cfg.AssetID, cfg.RewardAmount(), ret)
+		if err != nil {
+			return 0, err
+		}
+		ins = append(ins, assetIns...)
+		returnedOuts = append(returnedOuts, assetOuts...)
+		signers = append(signers, assetSigners...)
+		key.SortTransferableInputsWithSigners(ins, signers)
+		avax.SortTransferableOutputs(returnedOuts, txs.Codec)
+	}
+	subnetAuth, subnetSigners, err := pc.authorize(ctx, k, subnetID, ret)
+	if err != nil {
+		return 0, err
+	}
+	signers = append(signers, subnetSigners)
+
+	utx := &txs.TransformSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		Subnet:                   subnetID,
+		AssetID:                  cfg.AssetID,
+		InitialSupply:            cfg.InitialSupply,
+		MaximumSupply:            cfg.MaximumSupply,
+		MinConsumptionRate:       cfg.MinConsumptionRate,
+		MaxConsumptionRate:       cfg.MaxConsumptionRate,
+		MinValidatorStake:        cfg.MinValidatorStake,
+		MaxValidatorStake:        cfg.MaxValidatorStake,
+		MinStakeDuration:         uint32(cfg.MinStakeDuration / time.Second),
+		MaxStakeDuration:         uint32(cfg.MaxStakeDuration / time.Second),
+		MinDelegationFee:         cfg.MinDelegationFee,
+		MinDelegatorStake:        cfg.MinDelegatorStake,
+		MaxValidatorWeightFactor: cfg.MaxValidatorWeightFactor,
+		UptimeRequirement:        cfg.UptimeRequirement,
+		SubnetAuth:               subnetAuth,
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if ret.partialTx != nil {
+		return 0, pc.partialSign(k, pTx, signers, ret.partialTx)
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return 0, err
+	}
+	if err := utx.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return 0, err
+	}
+	if ret.dryMode {
+		return 0, pc.dryRun(pTx, ret)
+	}
+	txID, err := pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	return pc.checker.PollTx(ctx, txID, pstatus.Committed)
+}
+
+// ref. "platformvm.VM.newExportTx".
+func (pc *p) Export(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	amount uint64,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	txFee := uint64(fi.TxFee)
+	toBurn, err := math.Add64(amount, txFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	zap.L().Info("exporting from P-Chain",
+		zap.String("destinationChain", chainID.String()),
+		zap.Uint64("amount", amount),
+		zap.Uint64("txFee", txFee),
+	)
+	ins, returnedOuts, _, signers, err := pc.stake(ctx, k, toBurn, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	utx := &txs.ExportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         returnedOuts,
+		}},
+		DestinationChain: chainID,
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: pc.assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{k.Addresses()[0]},
+				},
+			},
+		}},
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.dryMode {
+		return pTx.ID(), 0, pc.dryRun(pTx, ret)
+	}
+	return pc.Issue(ctx, pTx)
+}
+
+// ref. "platformvm.VM.newImportTx".
+func (pc *p) Import(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	fi, err := pc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	txFee := uint64(fi.TxFee)
+
+	utxos, err := pc.utxos(ctx, k, chainID)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.assetID != ids.Empty && ret.assetID != pc.assetID {
+		return pc.importAsset(ctx, k, chainID, utxos, txFee, ret)
+	}
+	imported, ins, signers := spendAtomicAVAX(k, utxos, pc.assetID)
+	if len(ins) == 0 {
+		return ids.Empty, 0, ErrNoAtomicUTXOs
+	}
+	if imported <= txFee {
+		return ids.Empty, 0, fmt.Errorf("%w (imported %d, expected >%d)", ErrInsufficientBalanceForGasFee, imported, txFee)
+	}
+
+	zap.L().Info("importing to P-Chain",
+		zap.String("sourceChain", chainID.String()),
+		zap.Uint64("imported", imported),
+		zap.Uint64("txFee", txFee),
+	)
+	utx := &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: pc.assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: imported - txFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{k.Addresses()[0]},
+					},
+				},
+			}},
+		}},
+		SourceChain:    chainID,
+		ImportedInputs: ins,
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	if err := k.Sign(pTx, signers); err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.dryMode {
+		return pTx.ID(), 0, pc.dryRun(pTx, ret)
+	}
+	return pc.Issue(ctx, pTx)
+}
+
+// importAsset imports the asset of [ret] from the atomic [utxos],
+// paying the fee with the AVAX of the key on the P-Chain.
+func (pc *p) importAsset(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	utxos []*avax.UTXO,
+	txFee uint64,
+	ret *Op,
+) (txID ids.ID, took time.Duration, err error) {
+	imported, importedIns, importedSigners := spendAtomicAVAX(k, utxos, ret.assetID)
+	if len(importedIns) == 0 {
+		return ids.Empty, 0, fmt.Errorf("%w (asset %s)", ErrNoAtomicUTXOs, ret.assetID)
+	}
+
+	zap.L().Info("importing asset to P-Chain",
+		zap.String("sourceChain", chainID.String()),
+		zap.String("assetId", ret.assetID.String()),
+		zap.Uint64("imported", imported),
+		zap.Uint64("txFee", txFee),
+	)
+	ins, outs, _, signers, err := pc.stake(ctx, k, txFee, WithChangeOwner(ret.changeOwner), withSelectionOf(ret))
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	outs = append(outs, &avax.TransferableOutput{
+		Asset: avax.Asset{ID: ret.assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: imported,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{k.Addresses()[0]},
+			},
+		},
+	})
+	avax.SortTransferableOutputs(outs, txs.Codec)
+
+	utx := &txs.ImportTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    pc.networkID,
+			BlockchainID: pc.pChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		SourceChain:    chainID,
+		ImportedInputs: importedIns,
+	}
+	pTx := &txs.Tx{
+		Unsigned: utx,
+	}
+	// credentials of [ins] come before the ones of [importedIns]
+	signers = append(signers, importedSigners...)
+	if err := k.Sign(pTx, signers); err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.dryMode {
+		return pTx.ID(), 0, pc.dryRun(pTx, ret)
+	}
+	return pc.Issue(ctx, pTx)
+}
+
+func (pc *p) Issue(ctx context.Context, pTx *txs.Tx) (txID ids.ID, took time.Duration, err error) {
+	if err := pTx.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return ids.Empty, 0, err
+	}
+	zap.L().Info("issuing tx",
+		zap.String("txId", pTx.ID().String()),
+		zap.String("txType", fmt.Sprintf("%T", pTx.Unsigned)),
+	)
+	txID, err = pc.cli.IssueTx(ctx, pTx.Bytes())
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+	took, err = pc.checker.PollTx(ctx, txID, pstatus.Committed)
+	return txID, took, err
+}
+
+// dryRun verifies the signed [pTx] and stores it in [ret]
+// (if requested) instead of issuing it.
+func (pc *p) dryRun(pTx *txs.Tx, ret *Op) error {
+	if err := pTx.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return err
+	}
+	zap.L().Info("dry run, not issuing tx",
+		zap.String("txId", pTx.ID().String()),
+		zap.String("txType", fmt.Sprintf("%T", pTx.Unsigned)),
+	)
+	if ret.dryRunTx != nil {
+		*ret.dryRunTx = *pTx
+	}
+	return nil
+}
+
+// partialSign verifies the unsigned [pTx] and stores it in [ptx] with the
+// signatures [k] can provide, so the rest can be collected offline.
+func (pc *p) partialSign(k key.Key, pTx *txs.Tx, signers [][]ids.ShortID, ptx *PartialTx) error {
+	if err := pTx.Unsigned.SyntacticVerify(&snow.Context{
+		NetworkID:   pc.networkID,
+		ChainID:     pc.pChainID,
+		AVAXAssetID: pc.assetID,
+	}); err != nil {
+		return err
+	}
+	nptx, err := NewPartialTx(pc.networkID, pTx, signers)
+	if err != nil {
+		return err
+	}
+	*ptx = *nptx
+	signed, err := ptx.Sign(k)
+	if err != nil {
+		return err
+	}
+	zap.L().Info("built partially signed tx",
+		zap.String("txType", fmt.Sprintf("%T", pTx.Unsigned)),
+		zap.Int("signed", len(signed)),
+		zap.Int("missing", len(ptx.Missing())),
+	)
+	return nil
+}
+
+type Op struct {
+	stakeAmt     uint64
+	stakeAssetID ids.ID
+	// asset to export or import, AVAX if empty
+	assetID      ids.ID
+	rewardShares uint32
+	// BLS key of a primary network validator
+	proofOfPossession *signer.ProofOfPossession
+	rewardOwner       *secp256k1fx.OutputOwners
+	changeOwner       *secp256k1fx.OutputOwners
+
+	controlKeys []ids.ShortID
+	threshold   uint32
+
+	dryMode  bool
+	dryRunTx *txs.Tx
+	poll     bool
+
+	partialTx      *PartialTx
+	subnetAuthKeys []ids.ShortID
+
+	coinSelection   CoinSelection
+	utxoIDs         []ids.ID
+	excludedUTXOIDs []ids.ID
+}
+
+type OpOption func(*Op)
+
+func (op *Op) applyOpts(opts []OpOption) {
+	for _, opt := range opts {
+		opt(op)
 	}
 }
 
+func WithStakeAmount(v uint64) OpOption {
+	return func(op *Op) {
+		op.stakeAmt = v
+	}
+}
+
+// To stake the asset [v] instead of AVAX (e.g., on a permissionless subnet).
+func WithStakeAssetID(v ids.ID) OpOption {
+	return func(op *Op) {
+		op.stakeAssetID = v
+	}
+}
+
+// To export or import the asset [v] instead of AVAX.
+func WithAssetID(v ids.ID) OpOption {
+	return func(op *Op) {
+		op.assetID = v
+	}
+}
+
+// To register the BLS public key of [v] for a primary network validator.
+func WithProofOfPossession(v *signer.ProofOfPossession) OpOption {
+	return func(op *Op) {
+		op.proofOfPossession = v
+	}
+}
+
+func WithRewardShares(v uint32) OpOption {
+	return func(op *Op) {
+		op.rewardShares = v
+	}
+}
+
+// To send the rewards to [v] alone.
 func WithRewardAddress(v ids.ShortID) OpOption {
+	return WithRewardOwner(&secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{v}})
+}
+
+// To send the rewards to [v] (e.g., a multisig custody address set).
+// Addresses must be sorted and unique.
+func WithRewardOwner(v *secp256k1fx.OutputOwners) OpOption {
 	return func(op *Op) {
-		op.rewardAddr = v
+		op.rewardOwner = v
 	}
 }
 
+// To send the change and the unlocked stake to [v] alone.
 func WithChangeAddress(v ids.ShortID) OpOption {
+	return WithChangeOwner(&secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{v}})
+}
+
+// To send the change and the unlocked stake to [v].
+// Addresses must be sorted and unique.
+func WithChangeOwner(v *secp256k1fx.OutputOwners) OpOption {
+	return func(op *Op) {
+		op.changeOwner = v
+	}
+}
+
+// selfOwner returns the owner of the outputs sent back to [k].
+func selfOwner(k key.Key) *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{k.Addresses()[0]}}
+}
+
+// VerifyOwner returns an error if [owner] can't be spent or isn't
+// in canonical form.
+func VerifyOwner(owner *secp256k1fx.OutputOwners) error {
+	if err := owner.Verify(); err != nil {
+		return fmt.Errorf("%w (%v)", ErrInvalidOwner, err)
+	}
+	if len(owner.Addrs) == 0 {
+		return fmt.Errorf("%w (no address)", ErrInvalidOwner)
+	}
+	return nil
+}
+
+// formatOwner formats [owner] for logging.
+func formatOwner(owner *secp256k1fx.OutputOwners) string {
+	return fmt.Sprintf("%d-of-%v (locktime %d)", owner.Threshold, owner.Addrs, owner.Locktime)
+}
+
+// To set the control keys of a new subnet.
+// Must be sorted and unique.
+func WithControlKeys(v []ids.ShortID) OpOption {
 	return func(op *Op) {
-		op.changeAddr = v
+		op.controlKeys = v
+	}
+}
+
+// To set the number of control key signatures
+// required to manage a new subnet.
+func WithThreshold(v uint32) OpOption {
+	return func(op *Op) {
+		op.threshold = v
 	}
 }
 
@@ -664,12 +1852,88 @@ func WithDryMode(b bool) OpOption {
 	}
 }
 
+// WithDryRunTx builds, signs and verifies the transaction into [tx]
+// without issuing it.
+func WithDryRunTx(tx *txs.Tx) OpOption {
+	return func(op *Op) {
+		op.dryMode = true
+		op.dryRunTx = tx
+	}
+}
+
 func WithPoll(b bool) OpOption {
 	return func(op *Op) {
 		op.poll = b
 	}
 }
 
+// To build the transaction without issuing it, storing it in [ptx]
+// with the signatures the key can provide. The remaining signatures
+// (e.g., other subnet control keys) can then be collected offline.
+func WithPartialTx(ptx *PartialTx) OpOption {
+	return func(op *Op) {
+		op.partialTx = ptx
+	}
+}
+
+// To choose which subnet control keys sign the subnet auth
+// when building a partially signed transaction.
+func WithSubnetAuthKeys(v []ids.ShortID) OpOption {
+	return func(op *Op) {
+		op.subnetAuthKeys = v
+	}
+}
+
+// WithCoinSelection sets the order in which UTXOs are consumed.
+func WithCoinSelection(v CoinSelection) OpOption {
+	return func(op *Op) {
+		op.coinSelection = v
+	}
+}
+
+// WithUTXOIDs restricts the UTXOs to consume to the ones
+// with the given input IDs (see "ParseUTXOID").
+func WithUTXOIDs(v []ids.ID) OpOption {
+	return func(op *Op) {
+		op.utxoIDs = v
+	}
+}
+
+// WithExcludedUTXOIDs prevents the UTXOs with the given
+// input IDs (see "ParseUTXOID") from being consumed.
+func WithExcludedUTXOIDs(v []ids.ID) OpOption {
+	return func(op *Op) {
+		op.excludedUTXOIDs = v
+	}
+}
+
+// withSelectionOf copies the coin selection options of [ret].
+func withSelectionOf(ret *Op) OpOption {
+	return func(op *Op) {
+		op.coinSelection = ret.coinSelection
+		op.utxoIDs = ret.utxoIDs
+		op.excludedUTXOIDs = ret.excludedUTXOIDs
+	}
+}
+
+// utxos returns all UTXOs of the key on the P-Chain,
+// or the atomic UTXOs exported from [sourceChainID] if not empty.
+func (pc *p) utxos(ctx context.Context, k key.Key, sourceChainID ids.ID) ([]*avax.UTXO, error) {
+	sourceChain := ""
+	if sourceChainID != ids.Empty {
+		sourceChain = sourceChainID.String()
+	}
+	return internal_avax.GetAllUTXOs(ctx, k.Addresses(), func(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		limit uint32,
+		startAddr ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		return pc.cli.GetAtomicUTXOs(ctx, addrs, sourceChain, limit, startAddr, startUTXOID)
+	}, txs.Codec)
+}
+
 // ref. "platformvm.VM.stake".
 func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption) (
 	ins []*avax.TransferableInput,
@@ -680,14 +1944,29 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 ) {
 	ret := &Op{}
 	ret.applyOpts(opts)
-	if ret.rewardAddr == ids.ShortEmpty {
-		ret.rewardAddr = k.Addresses()[0]
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
 	}
-	if ret.changeAddr == ids.ShortEmpty {
-		ret.changeAddr = k.Addresses()[0]
+	if err := VerifyOwner(ret.changeOwner); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("%w: change owner", err)
+	}
+
+	utxos, err := pc.utxos(ctx, k, ids.Empty)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
-	ubs, _, _, err := pc.cli.GetAtomicUTXOs(ctx, k.Addresses(), "", 100, ids.ShortEmpty, ids.Empty)
+	// the fee is always burned in AVAX
+	stakeAssetID := pc.assetID
+	if ret.stakeAssetID != ids.Empty {
+		stakeAssetID = ret.stakeAssetID
+	}
+	toSpend := map[ids.ID]uint64{stakeAssetID: ret.stakeAmt}
+	toSpend[pc.assetID], err = math.Add64(toSpend[pc.assetID], fee)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	utxos, err = ret.selectAssetUTXOs(utxos, toSpend)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -698,24 +1977,15 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	returnedOuts = make([]*avax.TransferableOutput, 0)
 	stakedOuts = make([]*avax.TransferableOutput, 0)
 
-	utxos := make([]*avax.UTXO, len(ubs))
-	for i, ub := range ubs {
-		utxos[i], err = internal_avax.ParseUTXO(ub, txs.Codec)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-	}
-
-	// amount of AVAX that has been staked
+	// amount of the stake asset that has been staked
 	amountStaked := uint64(0)
 	for _, utxo := range utxos {
-		// have staked more AVAX then we need to
-		// no need to consume more AVAX
+		// have staked more than we need to
+		// no need to consume more
 		if amountStaked >= ret.stakeAmt {
 			break
 		}
-		// assume "AssetID" is set to "AVAX" asset ID
-		if utxo.AssetID() != pc.assetID {
+		if utxo.AssetID() != stakeAssetID {
 			continue
 		}
 
@@ -757,7 +2027,7 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 
 		// Add the output to the staked outputs
 		stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-			Asset: avax.Asset{ID: pc.assetID},
+			Asset: avax.Asset{ID: stakeAssetID},
 			Out: &stakeable.LockOut{
 				Locktime: out.Locktime,
 				TransferableOut: &secp256k1fx.TransferOutput{
@@ -770,16 +2040,11 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		if remainingValue > 0 {
 			// input had extra value, so some of it must be returned
 			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
+				Asset: avax.Asset{ID: stakeAssetID},
 				Out: &secp256k1fx.TransferOutput{
 					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-
-						// address to send change to, if there is any
-						Addrs: []ids.ShortID{ret.changeAddr},
-					},
+					// owner to send change to, if there is any
+					OutputOwners: *ret.changeOwner,
 				},
 			})
 		}
@@ -792,14 +2057,16 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	// amount of AVAX that has been burned
 	amountBurned := uint64(0)
 	for _, utxo := range utxos {
-		// have staked more AVAX then we need to
+		// have staked more than we need to
 		// have burned more AVAX then we need to
-		// no need to consume more AVAX
+		// no need to consume more
 		if amountStaked >= ret.stakeAmt && amountBurned >= fee {
 			break
 		}
-		// assume "AssetID" is set to "AVAX" asset ID
-		if utxo.AssetID() != pc.assetID {
+		assetID := utxo.AssetID()
+		needsBurn := assetID == pc.assetID && amountBurned < fee
+		needsStake := assetID == stakeAssetID && amountStaked < ret.stakeAmt
+		if !needsBurn && !needsStake {
 			continue
 		}
 
@@ -824,32 +2091,33 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		remainingValue := in.In.Amount()
 
 		// burn any value that should be burned
-		amountToBurn := math.Min64(
-			fee-amountBurned, // amount we still need to burn
-			remainingValue,   // amount available to burn
-		)
-		amountBurned += amountToBurn
-		remainingValue -= amountToBurn
+		if needsBurn {
+			amountToBurn := math.Min64(
+				fee-amountBurned, // amount we still need to burn
+				remainingValue,   // amount available to burn
+			)
+			amountBurned += amountToBurn
+			remainingValue -= amountToBurn
+		}
 
 		// stake any value that should be staked
-		amountToStake := math.Min64(
-			ret.stakeAmt-amountStaked, // Amount we still need to stake
-			remainingValue,            // Amount available to stake
-		)
-		amountStaked += amountToStake
-		remainingValue -= amountToStake
+		amountToStake := uint64(0)
+		if needsStake {
+			amountToStake = math.Min64(
+				ret.stakeAmt-amountStaked, // Amount we still need to stake
+				remainingValue,            // Amount available to stake
+			)
+			amountStaked += amountToStake
+			remainingValue -= amountToStake
+		}
 
 		if amountToStake > 0 {
 			// Some of this input was put for staking
 			stakedOuts = append(stakedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
+				Asset: avax.Asset{ID: stakeAssetID},
 				Out: &secp256k1fx.TransferOutput{
-					Amt: amountToStake,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-						Addrs:     []ids.ShortID{ret.changeAddr},
-					},
+					Amt:          amountToStake,
+					OutputOwners: *ret.changeOwner,
 				},
 			})
 		}
@@ -857,16 +2125,11 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		if remainingValue > 0 {
 			// input had extra value, so some of it must be returned
 			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: pc.assetID},
+				Asset: avax.Asset{ID: assetID},
 				Out: &secp256k1fx.TransferOutput{
 					Amt: remainingValue,
-					OutputOwners: secp256k1fx.OutputOwners{
-						Locktime:  0,
-						Threshold: 1,
-
-						// address to send change to, if there is any
-						Addrs: []ids.ShortID{ret.changeAddr},
-					},
+					// owner to send change to, if there is any
+					OutputOwners: *ret.changeOwner,
 				},
 			})
 		}
@@ -876,10 +2139,10 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 		signers = append(signers, inputSigners...)
 	}
 
-	if amountStaked > 0 && amountStaked < ret.stakeAmt {
-		return nil, nil, nil, nil, ErrInsufficientBalanceForStakeAmount
+	if amountStaked < ret.stakeAmt {
+		return nil, nil, nil, nil, fmt.Errorf("%w: asset %s (expected=%d, have=%d)", ErrInsufficientBalanceForStakeAmount, stakeAssetID, ret.stakeAmt, amountStaked)
 	}
-	if amountBurned > 0 && amountBurned < fee {
+	if amountBurned < fee {
 		return nil, nil, nil, nil, ErrInsufficientBalanceForGasFee
 	}
 
@@ -890,30 +2153,91 @@ func (pc *p) stake(ctx context.Context, k key.Key, fee uint64, opts ...OpOption)
 	return ins, returnedOuts, stakedOuts, signers, nil
 }
 
-// ref. "platformvm.VM.authorize".
-func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID) (
-	auth verify.Verifiable, // input that names owners
-	signers []ids.ShortID,
+// burn consumes [amount] of [assetID], which must not be AVAX, from
+// the unlocked UTXOs of the key, returning any change to the change
+// owner of [ret].
+func (pc *p) burn(ctx context.Context, k key.Key, assetID ids.ID, amount uint64, ret *Op) (
+	ins []*avax.TransferableInput,
+	returnedOuts []*avax.TransferableOutput,
+	signers [][]ids.ShortID,
 	err error,
 ) {
-	tb, err := pc.cli.GetTx(ctx, subnetID)
+	changeOwner := ret.changeOwner
+	if changeOwner == nil {
+		changeOwner = selfOwner(k)
+	}
+	utxos, err := pc.utxos(ctx, k, ids.Empty)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-
-	tx := new(txs.Tx)
-	if _, err = txs.Codec.Unmarshal(tb, tx); err != nil {
-		return nil, nil, err
+	utxos, err = ret.selectUTXOs(utxos, amount)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	subnetTx, ok := tx.Unsigned.(*txs.CreateSubnetTx)
-	if !ok {
-		return nil, nil, ErrWrongTxType
+	now := uint64(time.Now().Unix())
+	amountBurned := uint64(0)
+	for _, utxo := range utxos {
+		if amountBurned >= amount {
+			break
+		}
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		if inner, ok := utxo.Out.(*stakeable.LockOut); ok {
+			if inner.Locktime > now {
+				// output currently locked, can't be burned
+				continue
+			}
+			utxo.Out = inner.TransferableOut
+		}
+		_, inputs, inputSigners := k.Spends([]*avax.UTXO{utxo}, key.WithTime(now))
+		if len(inputs) == 0 {
+			// cannot spend this UTXO, skip to try next one
+			continue
+		}
+		in := inputs[0]
+
+		remainingValue := in.In.Amount()
+		amountToBurn := math.Min64(amount-amountBurned, remainingValue)
+		amountBurned += amountToBurn
+		remainingValue -= amountToBurn
+		if remainingValue > 0 {
+			returnedOuts = append(returnedOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          remainingValue,
+					OutputOwners: *changeOwner,
+				},
+			})
+		}
+		ins = append(ins, in)
+		signers = append(signers, inputSigners...)
+	}
+	if amountBurned < amount {
+		return nil, nil, nil, fmt.Errorf("%w: asset %s (expected=%d, have=%d)", ErrInsufficientBalance, assetID, amount, amountBurned)
 	}
+	return ins, returnedOuts, signers, nil
+}
 
-	owner, ok := subnetTx.Owner.(*secp256k1fx.OutputOwners)
-	if !ok {
-		return nil, nil, ErrUnknownOwners
+// ref. "platformvm.VM.authorize".
+func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID, ret *Op) (
+	auth verify.Verifiable, // input that names owners
+	signers []ids.ShortID,
+	err error,
+) {
+	owner, err := pc.subnetOwners(ctx, subnetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ret.partialTx != nil {
+		// signatures are collected offline,
+		// so [k] does not need to meet the threshold
+		indices, signers, err := matchPartial(k, owner, ret.subnetAuthKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &secp256k1fx.Input{SigIndices: indices}, signers, nil
 	}
 	now := uint64(time.Now().Unix())
 	indices, signers, ok := k.Match(owner, now)
@@ -922,3 +2246,56 @@ func (pc *p) authorize(ctx context.Context, k key.Key, subnetID ids.ID) (
 	}
 	return &secp256k1fx.Input{SigIndices: indices}, signers, nil
 }
+
+// matchPartial selects [owners.Threshold] owners to sign. If [authKeys] is
+// not specified, owners held by [k] are selected first and the rest are
+// filled in order.
+func matchPartial(k key.Key, owners *secp256k1fx.OutputOwners, authKeys []ids.ShortID) ([]uint32, []ids.ShortID, error) {
+	selected := make([]bool, len(owners.Addrs))
+	n := uint32(0)
+	if len(authKeys) > 0 {
+		if uint32(len(authKeys)) != owners.Threshold {
+			return nil, nil, fmt.Errorf("%w (expected %d keys, got %d)", ErrInvalidSubnetAuthKeys, owners.Threshold, len(authKeys))
+		}
+		for _, authKey := range authKeys {
+			found := false
+			for i, addr := range owners.Addrs {
+				if addr == authKey && !selected[i] {
+					selected[i], found = true, true
+					n++
+					break
+				}
+			}
+			if !found {
+				return nil, nil, fmt.Errorf("%w (%s is not a control key)", ErrInvalidSubnetAuthKeys, authKey)
+			}
+		}
+	} else {
+		held := map[ids.ShortID]struct{}{}
+		for _, addr := range k.Addresses() {
+			held[addr] = struct{}{}
+		}
+		for i, addr := range owners.Addrs {
+			if _, ok := held[addr]; ok && n < owners.Threshold {
+				selected[i] = true
+				n++
+			}
+		}
+		for i := range owners.Addrs {
+			if !selected[i] && n < owners.Threshold {
+				selected[i] = true
+				n++
+			}
+		}
+	}
+
+	indices := make([]uint32, 0, owners.Threshold)
+	signers := make([]ids.ShortID, 0, owners.Threshold)
+	for i, ok := range selected {
+		if ok {
+			indices = append(indices, uint32(i))
+			signers = append(signers, owners.Addrs[i])
+		}
+	}
+	return indices, signers, nil
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+var (
+	ErrMissingSignatures  = errors.New("missing signatures")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrMismatchPartialTxs = errors.New("mismatched partial transactions")
+	ErrMismatchNetworkID  = errors.New("mismatched network ID")
+)
+
+const partialTxFileMode = 0o600
+
+var sigFactory = new(crypto.FactorySECP256K1R)
+
+// PartialTx is a P-Chain transaction that has been built but not yet
+// signed by all of its required signers (e.g., the control keys of
+// a subnet), so that signatures can be collected offline.
+type PartialTx struct {
+	NetworkID uint32
+	// Tx is the transaction without credentials.
+	Tx *txs.Tx
+	// Signers are the addresses required to sign each credential.
+	Signers [][]ids.ShortID
+	// Sigs are the signatures over the unsigned transaction hash
+	// collected so far, keyed by signer address.
+	Sigs map[ids.ShortID][]byte
+
+	unsignedBytes []byte
+	hash          []byte
+}
+
+type partialTxJSON struct {
+	NetworkID  uint32            `json:"networkId"`
+	UnsignedTx string            `json:"unsignedTx"`
+	Signers    [][]ids.ShortID   `json:"signers"`
+	Signatures map[string]string `json:"signatures"`
+}
+
+// NewPartialTx creates a PartialTx from the unsigned [pTx]
+// that must be signed by [signers].
+func NewPartialTx(networkID uint32, pTx *txs.Tx, signers [][]ids.ShortID) (*PartialTx, error) {
+	ptx := &PartialTx{
+		NetworkID: networkID,
+		Tx:        &txs.Tx{Unsigned: pTx.Unsigned},
+		Signers:   signers,
+		Sigs:      map[ids.ShortID][]byte{},
+	}
+	var err error
+	ptx.unsignedBytes, ptx.hash, err = key.UnsignedHash(ptx.Tx)
+	if err != nil {
+		return nil, err
+	}
+	// initialize the bytes of the transaction without
+	// credentials, so that it can be summarized
+	signedBytes, err := txs.Codec.Marshal(txs.Version, ptx.Tx)
+	if err != nil {
+		return nil, err
+	}
+	ptx.Tx.Initialize(ptx.unsignedBytes, signedBytes)
+	return ptx, nil
+}
+
+// LoadPartialTx loads a PartialTx previously saved at [p].
+func LoadPartialTx(p string) (*PartialTx, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	var pj partialTxJSON
+	if err := json.Unmarshal(b, &pj); err != nil {
+		return nil, err
+	}
+	unsignedBytes, err := formatting.Decode(formatting.Hex, pj.UnsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	var utx txs.UnsignedTx
+	if _, err := txs.Codec.Unmarshal(unsignedBytes, &utx); err != nil {
+		return nil, err
+	}
+	btx, err := baseTx(utx)
+	if err != nil {
+		return nil, err
+	}
+	if btx.NetworkID != pj.NetworkID {
+		return nil, fmt.Errorf("%w: transaction for %d, expected %d", ErrMismatchNetworkID, btx.NetworkID, pj.NetworkID)
+	}
+	ptx, err := NewPartialTx(pj.NetworkID, &txs.Tx{Unsigned: utx}, pj.Signers)
+	if err != nil {
+		return nil, err
+	}
+	for rsigner, rsig := range pj.Signatures {
+		signer, err := ids.ShortFromString(rsigner)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := formatting.Decode(formatting.Hex, rsig)
+		if err != nil {
+			return nil, err
+		}
+		if err := ptx.addSig(signer, sig); err != nil {
+			return nil, err
+		}
+	}
+	return ptx, nil
+}
+
+// Save writes the PartialTx to disk in JSON.
+func (ptx *PartialTx) Save(p string) error {
+	utx, err := formatting.Encode(formatting.Hex, ptx.unsignedBytes)
+	if err != nil {
+		return err
+	}
+	pj := partialTxJSON{
+		NetworkID:  ptx.NetworkID,
+		UnsignedTx: utx,
+		Signers:    ptx.Signers,
+		Signatures: make(map[string]string, len(ptx.Sigs)),
+	}
+	for signer, sig := range ptx.Sigs {
+		pj.Signatures[signer.String()], err = formatting.Encode(formatting.Hex, sig)
+		if err != nil {
+			return err
+		}
+	}
+	b, err := json.MarshalIndent(pj, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, partialTxFileMode)
+}
+
+// Required returns the unique addresses that must sign the transaction.
+func (ptx *PartialTx) Required() []ids.ShortID {
+	seen := map[ids.ShortID]struct{}{}
+	required := []ids.ShortID{}
+	for _, inputSigners := range ptx.Signers {
+		for _, signer := range inputSigners {
+			if _, ok := seen[signer]; ok {
+				continue
+			}
+			seen[signer] = struct{}{}
+			required = append(required, signer)
+		}
+	}
+	return required
+}
+
+// Missing returns the addresses that have not yet signed the transaction.
+func (ptx *PartialTx) Missing() []ids.ShortID {
+	missing := []ids.ShortID{}
+	for _, signer := range ptx.Required() {
+		if _, ok := ptx.Sigs[signer]; !ok {
+			missing = append(missing, signer)
+		}
+	}
+	return missing
+}
+
+// Sign adds the signatures of all missing signers held by [k],
+// and returns the addresses that signed. A watch-only key signs
+// nothing, so the transaction is left unsigned for export.
+func (ptx *PartialTx) Sign(k key.Key) ([]ids.ShortID, error) {
+	held := map[ids.ShortID]struct{}{}
+	for _, addr := range k.Addresses() {
+		held[addr] = struct{}{}
+	}
+	signers := []ids.ShortID{}
+	for _, signer := range ptx.Missing() {
+		if _, ok := held[signer]; ok {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) == 0 {
+		return signers, nil
+	}
+
+	sigs, err := k.SignHash(ptx.hash, signers)
+	if errors.Is(err, key.ErrWatchOnly) {
+		return []ids.ShortID{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, signer := range signers {
+		if err := ptx.addSig(signer, sigs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return signers, nil
+}
+
+// Merge adds the signatures collected in [other],
+// which must be built from the same unsigned transaction.
+func (ptx *PartialTx) Merge(other *PartialTx) error {
+	if !bytes.Equal(ptx.unsignedBytes, other.unsignedBytes) {
+		return ErrMismatchPartialTxs
+	}
+	for signer, sig := range other.Sigs {
+		if err := ptx.addSig(signer, sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Signed returns the transaction with all of its credentials attached.
+func (ptx *PartialTx) Signed() (*txs.Tx, error) {
+	if missing := ptx.Missing(); len(missing) > 0 {
+		return nil, fmt.Errorf("%w (%d remaining)", ErrMissingSignatures, len(missing))
+	}
+	pTx := &txs.Tx{Unsigned: ptx.Tx.Unsigned}
+	if err := key.AttachCredentials(pTx, ptx.unsignedBytes, ptx.Signers, ptx.Sigs); err != nil {
+		return nil, err
+	}
+	return pTx, nil
+}
+
+// addSig verifies that [sig] is the signature of [signer]
+// over the unsigned transaction hash before adding it.
+func (ptx *PartialTx) addSig(signer ids.ShortID, sig []byte) error {
+	pk, err := sigFactory.RecoverHashPublicKey(ptx.hash, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if pk.Address() != signer {
+		return fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignature, pk.Address(), signer)
+	}
+	ptx.Sigs[signer] = sig
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+func newTestPartialTx(t *testing.T, networkID uint32, signers [][]ids.ShortID) *PartialTx {
+	t.Helper()
+
+	pTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    networkID,
+			BlockchainID: ids.GenerateTestID(),
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}}
+	ptx, err := NewPartialTx(networkID, pTx, signers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ptx
+}
+
+func newTestKeys(t *testing.T) (*key.SoftKey, *key.SoftKey) {
+	t.Helper()
+
+	k1, err := key.NewSoft(constants.LocalID, key.WithPrivateKeyEncoded(key.EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := key.NewSoft(constants.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k1, k2
+}
+
+func TestPartialTxRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	k1, k2 := newTestKeys(t)
+	a1, a2 := k1.Addresses()[0], k2.Addresses()[0]
+	signers := [][]ids.ShortID{{a1}, {a1, a2}}
+	ptx := newTestPartialTx(t, constants.LocalID, signers)
+
+	signed, err := ptx.Sign(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 1 || signed[0] != a1 {
+		t.Fatalf("unexpected signers %v, expected %v", signed, a1)
+	}
+	if _, err := ptx.Signed(); !errors.Is(err, ErrMissingSignatures) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMissingSignatures)
+	}
+
+	p := filepath.Join(t.TempDir(), "tx.json")
+	if err := ptx.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadPartialTx(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.NetworkID != constants.LocalID {
+		t.Fatalf("unexpected network ID %d, expected %d", loaded.NetworkID, constants.LocalID)
+	}
+	if loaded.Tx.ID() != ptx.Tx.ID() {
+		t.Fatalf("unexpected tx ID %s, expected %s", loaded.Tx.ID(), ptx.Tx.ID())
+	}
+	if missing := loaded.Missing(); len(missing) != 1 || missing[0] != a2 {
+		t.Fatalf("unexpected missing signers %v, expected %v", missing, a2)
+	}
+
+	// the second signer signs its own copy, which is merged back
+	if _, err := loaded.Sign(k2); err != nil {
+		t.Fatal(err)
+	}
+	if err := ptx.Merge(loaded); err != nil {
+		t.Fatal(err)
+	}
+	pTx, err := ptx.Signed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pTx.Creds) != len(signers) {
+		t.Fatalf("unexpected %d credentials, expected %d", len(pTx.Creds), len(signers))
+	}
+	for i, cred := range pTx.Creds {
+		if n := len(cred.(*secp256k1fx.Credential).Sigs); n != len(signers[i]) {
+			t.Fatalf("unexpected %d signatures in credential %d, expected %d", n, i, len(signers[i]))
+		}
+	}
+}
+
+func TestPartialTxMergeMismatch(t *testing.T) {
+	t.Parallel()
+
+	k1, _ := newTestKeys(t)
+	signers := [][]ids.ShortID{{k1.Addresses()[0]}}
+	ptx := newTestPartialTx(t, constants.LocalID, signers)
+	other := newTestPartialTx(t, constants.LocalID, signers)
+	if _, err := other.Sign(k1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ptx.Merge(other); !errors.Is(err, ErrMismatchPartialTxs) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMismatchPartialTxs)
+	}
+	if len(ptx.Sigs) != 0 {
+		t.Fatalf("unexpected signatures %v", ptx.Sigs)
+	}
+}
+
+func TestPartialTxWrongSigner(t *testing.T) {
+	t.Parallel()
+
+	k1, k2 := newTestKeys(t)
+	a1, a2 := k1.Addresses()[0], k2.Addresses()[0]
+	ptx := newTestPartialTx(t, constants.LocalID, [][]ids.ShortID{{a1, a2}})
+
+	sigs, err := k2.SignHash(ptx.hash, []ids.ShortID{a2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ptx.addSig(a1, sigs[0]); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidSignature)
+	}
+	if err := ptx.addSig(a1, []byte{1, 2, 3}); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidSignature)
+	}
+	if len(ptx.Sigs) != 0 {
+		t.Fatalf("unexpected signatures %v", ptx.Sigs)
+	}
+
+	// a signature saved under another signer is rejected on load
+	if err := ptx.addSig(a2, sigs[0]); err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(t.TempDir(), "tx.json")
+	if err := ptx.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	rewritePartialTx(t, p, func(pj *partialTxJSON) {
+		pj.Signatures = map[string]string{a1.String(): pj.Signatures[a2.String()]}
+	})
+	if _, err := LoadPartialTx(p); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestLoadPartialTxNetworkID(t *testing.T) {
+	t.Parallel()
+
+	k1, _ := newTestKeys(t)
+	ptx := newTestPartialTx(t, constants.LocalID, [][]ids.ShortID{{k1.Addresses()[0]}})
+	p := filepath.Join(t.TempDir(), "tx.json")
+	if err := ptx.Save(p); err != nil {
+		t.Fatal(err)
+	}
+	rewritePartialTx(t, p, func(pj *partialTxJSON) {
+		pj.NetworkID = constants.MainnetID
+	})
+	if _, err := LoadPartialTx(p); !errors.Is(err, ErrMismatchNetworkID) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMismatchNetworkID)
+	}
+}
+
+// rewritePartialTx edits the JSON of the partial tx saved at [p].
+func rewritePartialTx(t *testing.T, p string, f func(*partialTxJSON)) {
+	t.Helper()
+
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pj partialTxJSON
+	if err := json.Unmarshal(b, &pj); err != nil {
+		t.Fatal(err)
+	}
+	f(&pj)
+	b, err = json.Marshal(pj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, b, partialTxFileMode); err != nil {
+		t.Fatal(err)
+	}
+}
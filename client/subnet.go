@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/api"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var ErrSubnetNotFound = errors.New("subnet not found")
+
+// Subnet is the state of a subnet on the P-Chain.
+type Subnet struct {
+	ID ids.ID
+	// Owners are the control keys and threshold of the CreateSubnetTx.
+	Owners            *secp256k1fx.OutputOwners
+	Validators        []*Validator
+	PendingValidators []*Validator
+	Blockchains       []*Blockchain
+}
+
+// Blockchain is a blockchain validated by a subnet.
+type Blockchain struct {
+	ID     ids.ID
+	Name   string
+	VMID   ids.ID
+	Status pstatus.BlockchainStatus
+}
+
+func (pc *p) GetSubnet(ctx context.Context, subnetID ids.ID) (*Subnet, error) {
+	ss, err := pc.cli.GetSubnets(ctx, []ids.ID{subnetID})
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) != 1 || ss[0].ID != subnetID {
+		return nil, ErrSubnetNotFound
+	}
+	owners, err := pc.subnetOwners(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	s := &Subnet{ID: subnetID, Owners: owners}
+
+	vs, err := pc.cli.GetCurrentValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.Validators = make([]*Validator, len(vs))
+	for i, v := range vs {
+		s.Validators[i] = newValidator(v)
+	}
+	s.PendingValidators, err = pc.pendingValidators(ctx, subnetID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bcs, err := pc.cli.GetBlockchains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bc := range bcs {
+		if bc.SubnetID != subnetID {
+			continue
+		}
+		status, err := pc.cli.GetBlockchainStatus(ctx, bc.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		s.Blockchains = append(s.Blockchains, &Blockchain{
+			ID:     bc.ID,
+			Name:   bc.Name,
+			VMID:   bc.VMID,
+			Status: status,
+		})
+	}
+	return s, nil
+}
+
+// subnetOwners decodes the control keys of [subnetID] from its CreateSubnetTx.
+func (pc *p) subnetOwners(ctx context.Context, subnetID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	tb, err := pc.cli.GetTx(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(txs.Tx)
+	if _, err = txs.Codec.Unmarshal(tb, tx); err != nil {
+		return nil, err
+	}
+
+	subnetTx, ok := tx.Unsigned.(*txs.CreateSubnetTx)
+	if !ok {
+		return nil, ErrWrongTxType
+	}
+
+	owner, ok := subnetTx.Owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, ErrUnknownOwners
+	}
+	return owner, nil
+}
+
+// pendingValidators returns the validators of [subnetID] that have not
+// started validating yet, filtered by [nodeIDs] if not empty.
+func (pc *p) pendingValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID) ([]*Validator, error) {
+	vs, _, err := pc.cli.GetPendingValidators(ctx, subnetID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	vdrs := make([]*Validator, len(vs))
+	for i, v := range vs {
+		// the API client returns the validators as decoded JSON objects
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var apiVdr api.PermissionlessValidator
+		if err := json.Unmarshal(b, &apiVdr); err != nil {
+			return nil, err
+		}
+		vdr := &Validator{
+			TxID:          apiVdr.TxID,
+			NodeID:        apiVdr.NodeID,
+			State:         ValidatorStatePending,
+			Start:         time.Unix(int64(apiVdr.StartTime), 0),
+			End:           time.Unix(int64(apiVdr.EndTime), 0),
+			DelegationFee: float32(apiVdr.DelegationFee),
+		}
+		switch {
+		case apiVdr.StakeAmount != nil:
+			vdr.Weight = uint64(*apiVdr.StakeAmount)
+		case apiVdr.Weight != nil:
+			vdr.Weight = uint64(*apiVdr.Weight)
+		}
+		vdrs[i] = vdr
+	}
+	return vdrs, nil
+}
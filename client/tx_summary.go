@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+var ErrUnknownCredential = errors.New("unknown credential type")
+
+// TxSummary describes what a P-Chain transaction consumes and produces.
+type TxSummary struct {
+	TxID      ids.ID
+	TxType    string
+	NetworkID uint32
+	// Ins includes the inputs imported from other chains.
+	Ins []*avax.TransferableInput
+	// Outs includes the staked and exported outputs.
+	Outs []*avax.TransferableOutput
+	// Burned is the amount of each asset burned by the transaction
+	// (e.g., the fee in AVAX).
+	Burned map[ids.ID]uint64
+	Memo   []byte
+	// Signers are the addresses that signed each credential.
+	Signers [][]ids.ShortID
+	Bytes   []byte
+}
+
+// staker is implemented by the transactions that stake AVAX.
+type staker interface {
+	Stake() []*avax.TransferableOutput
+}
+
+// SummarizeTx summarizes the signed P-Chain transaction [pTx].
+func SummarizeTx(pTx *txs.Tx) (*TxSummary, error) {
+	btx, err := baseTx(pTx.Unsigned)
+	if err != nil {
+		return nil, err
+	}
+	ins := append([]*avax.TransferableInput{}, btx.Ins...)
+	outs := append([]*avax.TransferableOutput{}, btx.Outs...)
+	switch utx := pTx.Unsigned.(type) {
+	case *txs.ImportTx:
+		ins = append(ins, utx.ImportedInputs...)
+	case *txs.ExportTx:
+		outs = append(outs, utx.ExportedOutputs...)
+	case staker:
+		outs = append(outs, utx.Stake()...)
+	}
+
+	consumed, produced := map[ids.ID]uint64{}, map[ids.ID]uint64{}
+	for _, in := range ins {
+		assetID := in.AssetID()
+		consumed[assetID], err = math.Add64(consumed[assetID], in.In.Amount())
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, out := range outs {
+		assetID := out.AssetID()
+		produced[assetID], err = math.Add64(produced[assetID], out.Out.Amount())
+		if err != nil {
+			return nil, err
+		}
+	}
+	for assetID, amount := range produced {
+		if _, err := math.Sub64(consumed[assetID], amount); err != nil {
+			return nil, fmt.Errorf("tx produces more %s than it consumes: %w", assetID, err)
+		}
+	}
+	burned := make(map[ids.ID]uint64, len(consumed))
+	for assetID, amount := range consumed {
+		if b := amount - produced[assetID]; b > 0 {
+			burned[assetID] = b
+		}
+	}
+
+	creds := make([]*secp256k1fx.Credential, len(pTx.Creds))
+	for i, cred := range pTx.Creds {
+		secpCred, ok := cred.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnknownCredential, cred)
+		}
+		creds[i] = secpCred
+	}
+	signers, err := key.Signers(pTx.Unsigned.Bytes(), creds)
+	if err != nil {
+		return nil, err
+	}
+	return &TxSummary{
+		TxID:      pTx.ID(),
+		TxType:    fmt.Sprintf("%T", pTx.Unsigned),
+		NetworkID: btx.NetworkID,
+		Ins:       ins,
+		Outs:      outs,
+		Burned:    burned,
+		Memo:      btx.Memo,
+		Signers:   signers,
+		Bytes:     pTx.Bytes(),
+	}, nil
+}
+
+// baseTx returns the inputs and outputs common to all transactions.
+func baseTx(utx txs.UnsignedTx) (*txs.BaseTx, error) {
+	switch utx := utx.(type) {
+	case *txs.AdvanceTimeTx, *txs.RewardValidatorTx:
+		// issued by the network, without inputs or outputs
+		return &txs.BaseTx{}, nil
+	case *txs.CreateSubnetTx:
+		return &utx.BaseTx, nil
+	case *txs.CreateChainTx:
+		return &utx.BaseTx, nil
+	case *txs.AddValidatorTx:
+		return &utx.BaseTx, nil
+	case *txs.AddDelegatorTx:
+		return &utx.BaseTx, nil
+	case *txs.AddSubnetValidatorTx:
+		return &utx.BaseTx, nil
+	case *txs.RemoveSubnetValidatorTx:
+		return &utx.BaseTx, nil
+	case *txs.ExportTx:
+		return &utx.BaseTx, nil
+	case *txs.ImportTx:
+		return &utx.BaseTx, nil
+	case *txs.TransformSubnetTx:
+		return &utx.BaseTx, nil
+	case *txs.AddPermissionlessValidatorTx:
+		return &utx.BaseTx, nil
+	case *txs.AddPermissionlessDelegatorTx:
+		return &utx.BaseTx, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrWrongTxType, utx)
+	}
+}
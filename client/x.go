@@ -0,0 +1,468 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api_info "github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	avm_txs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	wallet_x "github.com/ava-labs/avalanchego/wallet/chain/x"
+	internal_avax "github.com/ava-labs/subnet-cli/internal/avax"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/internal/poll"
+	"go.uber.org/zap"
+)
+
+// number of feature extensions registered on the X-Chain
+// ref. "wallet/chain/x.Parser"
+const xNumFxs = 3
+
+type X interface {
+	Client() avm.Client
+	// Balance returns the spendable AVAX of the key on the X-Chain.
+	Balance(ctx context.Context, k key.Key) (uint64, error)
+	// CreateAsset creates a fixed-cap asset minted to [holders], and
+	// returns its ID.
+	CreateAsset(
+		ctx context.Context,
+		k key.Key,
+		name string,
+		symbol string,
+		denomination byte,
+		holders []*AssetHolder,
+		opts ...OpOption,
+	) (assetID ids.ID, took time.Duration, err error)
+	// Export exports [amount] AVAX (or the asset set with WithAssetID)
+	// to the key on [chainID].
+	Export(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		amount uint64,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+	// Import imports all AVAX exported to the key from [chainID].
+	Import(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		opts ...OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+}
+
+// AssetHolder is an initial holder of an asset created on the X-Chain.
+type AssetHolder struct {
+	Addr   ids.ShortID
+	Amount uint64
+}
+
+type x struct {
+	cfg       Config
+	networkID uint32
+	assetID   ids.ID
+	xChainID  ids.ID
+
+	cli    avm.Client
+	info   api_info.Client
+	poller poll.Poller
+}
+
+func (xc *x) Client() avm.Client { return xc.cli }
+
+func (xc *x) Balance(ctx context.Context, k key.Key) (uint64, error) {
+	utxos, err := xc.utxos(ctx, k, ids.Empty)
+	if err != nil {
+		return 0, err
+	}
+	balance, _, _ := k.Spends(utxos, key.WithTime(uint64(time.Now().Unix())))
+	return balance, nil
+}
+
+// ref. "wallet/chain/x.builder.NewCreateAssetTx".
+func (xc *x) CreateAsset(
+	ctx context.Context,
+	k key.Key,
+	name string,
+	symbol string,
+	denomination byte,
+	holders []*AssetHolder,
+	opts ...OpOption,
+) (assetID ids.ID, took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+	}
+	if err := VerifyOwner(ret.changeOwner); err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: change owner", err)
+	}
+	if len(holders) == 0 {
+		return ids.Empty, 0, fmt.Errorf("%w: no initial holders", ErrInvalidSupply)
+	}
+
+	fi, err := xc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	txFee := uint64(fi.CreateAssetTxFee)
+
+	state := &avm_txs.InitialState{FxIndex: 0}
+	supply := uint64(0)
+	for _, holder := range holders {
+		if holder.Amount == 0 {
+			return ids.Empty, 0, fmt.Errorf("%w: zero amount for %s", ErrInvalidSupply, holder.Addr)
+		}
+		supply, err = math.Add64(supply, holder.Amount)
+		if err != nil {
+			return ids.Empty, 0, fmt.Errorf("%w: %v", ErrInvalidSupply, err)
+		}
+		state.Outs = append(state.Outs, &secp256k1fx.TransferOutput{
+			Amt: holder.Amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{holder.Addr},
+			},
+		})
+	}
+	state.Sort(wallet_x.Parser.Codec())
+
+	zap.L().Info("creating asset on X-Chain",
+		zap.String("name", name),
+		zap.String("symbol", symbol),
+		zap.Uint64("supply", supply),
+		zap.Uint64("txFee", txFee),
+	)
+	utxos, err := xc.utxos(ctx, k, ids.Empty)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	utxos, err = ret.selectUTXOs(utxos, txFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	ins, changeOuts, signers, err := spendAVAX(k, utxos, xc.assetID, txFee, ret.changeOwner)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	avax.SortTransferableOutputs(changeOuts, wallet_x.Parser.Codec())
+
+	utx := &avm_txs.CreateAssetTx{
+		BaseTx: avm_txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    xc.networkID,
+			BlockchainID: xc.xChainID,
+			Ins:          ins,
+			Outs:         changeOuts,
+		}},
+		Name:         name,
+		Symbol:       symbol,
+		Denomination: denomination,
+		States:       []*avm_txs.InitialState{state},
+	}
+	// the ID of the transaction is the ID of the asset
+	return xc.issue(ctx, k, &avm_txs.Tx{Unsigned: utx}, signers, txFee)
+}
+
+// ref. "wallet/chain/x.builder.NewExportTx".
+func (xc *x) Export(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	amount uint64,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+	if ret.changeOwner == nil {
+		ret.changeOwner = selfOwner(k)
+	}
+	if err := VerifyOwner(ret.changeOwner); err != nil {
+		return ids.Empty, 0, fmt.Errorf("%w: change owner", err)
+	}
+
+	if ret.assetID == ids.Empty {
+		ret.assetID = xc.assetID
+	}
+
+	fi, err := xc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	txFee := uint64(fi.TxFee)
+	toSpend := map[ids.ID]uint64{ret.assetID: amount}
+	toSpend[xc.assetID], err = math.Add64(toSpend[xc.assetID], txFee)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+
+	zap.L().Info("exporting from X-Chain",
+		zap.String("destinationChain", chainID.String()),
+		zap.String("assetId", ret.assetID.String()),
+		zap.Uint64("amount", amount),
+		zap.Uint64("txFee", txFee),
+	)
+	utxos, err := xc.utxos(ctx, k, ids.Empty)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	utxos, err = ret.selectAssetUTXOs(utxos, toSpend)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	ins, changeOuts, signers, err := spendAVAX(k, utxos, xc.assetID, toSpend[xc.assetID], ret.changeOwner)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	if ret.assetID != xc.assetID {
+		// the fee is paid in AVAX, the exported amount in the asset
+		assetIns, assetChangeOuts, assetSigners, err := spendAVAX(k, utxos, ret.assetID, amount, ret.changeOwner)
+		if err != nil {
+			return ids.Empty, 0, fmt.Errorf("%w (asset %s)", err, ret.assetID)
+		}
+		ins = append(ins, assetIns...)
+		changeOuts = append(changeOuts, assetChangeOuts...)
+		signers = append(signers, assetSigners...)
+		key.SortTransferableInputsWithSigners(ins, signers)
+	}
+	avax.SortTransferableOutputs(changeOuts, wallet_x.Parser.Codec())
+
+	utx := &avm_txs.ExportTx{
+		BaseTx: avm_txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    xc.networkID,
+			BlockchainID: xc.xChainID,
+			Ins:          ins,
+			Outs:         changeOuts,
+		}},
+		DestinationChain: chainID,
+		ExportedOuts: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: ret.assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  0,
+					Threshold: 1,
+					Addrs:     []ids.ShortID{k.Addresses()[0]},
+				},
+			},
+		}},
+	}
+	return xc.issue(ctx, k, &avm_txs.Tx{Unsigned: utx}, signers, txFee)
+}
+
+// ref. "wallet/chain/x.builder.NewImportTx".
+func (xc *x) Import(
+	ctx context.Context,
+	k key.Key,
+	chainID ids.ID,
+	opts ...OpOption,
+) (txID ids.ID, took time.Duration, err error) {
+	fi, err := xc.info.GetTxFee(ctx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	txFee := uint64(fi.TxFee)
+
+	utxos, err := xc.utxos(ctx, k, chainID)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	imported, ins, signers := spendAtomicAVAX(k, utxos, xc.assetID)
+	if len(ins) == 0 {
+		return ids.Empty, 0, ErrNoAtomicUTXOs
+	}
+	if imported <= txFee {
+		return ids.Empty, 0, fmt.Errorf("%w (imported %d, expected >%d)", ErrInsufficientBalanceForGasFee, imported, txFee)
+	}
+
+	zap.L().Info("importing to X-Chain",
+		zap.String("sourceChain", chainID.String()),
+		zap.Uint64("imported", imported),
+		zap.Uint64("txFee", txFee),
+	)
+	utx := &avm_txs.ImportTx{
+		BaseTx: avm_txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    xc.networkID,
+			BlockchainID: xc.xChainID,
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: xc.assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: imported - txFee,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{k.Addresses()[0]},
+					},
+				},
+			}},
+		}},
+		SourceChain: chainID,
+		ImportedIns: ins,
+	}
+	return xc.issue(ctx, k, &avm_txs.Tx{Unsigned: utx}, signers, txFee)
+}
+
+// utxos returns the UTXOs of the key on the X-Chain,
+// or the atomic UTXOs exported from [sourceChainID] if not empty.
+func (xc *x) utxos(ctx context.Context, k key.Key, sourceChainID ids.ID) ([]*avax.UTXO, error) {
+	return internal_avax.GetAllUTXOs(ctx, k.Addresses(), func(
+		ctx context.Context,
+		addrs []ids.ShortID,
+		limit uint32,
+		startAddr ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		if sourceChainID == ids.Empty {
+			return xc.cli.GetUTXOs(ctx, addrs, limit, startAddr, startUTXOID)
+		}
+		return xc.cli.GetAtomicUTXOs(ctx, addrs, sourceChainID.String(), limit, startAddr, startUTXOID)
+	}, wallet_x.Parser.Codec())
+}
+
+// issue signs [tx] with [k], issues it, and waits for it to be accepted.
+func (xc *x) issue(
+	ctx context.Context,
+	k key.Key,
+	tx *avm_txs.Tx,
+	signers [][]ids.ShortID,
+	txFee uint64,
+) (txID ids.ID, took time.Duration, err error) {
+	cdc := wallet_x.Parser.Codec()
+	unsignedBytes, err := cdc.Marshal(avm_txs.CodecVersion, &tx.Unsigned)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	sigs, err := key.SignSigners(k, hashing.ComputeHash256(unsignedBytes), signers)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	creds, err := key.Credentials(signers, sigs)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	for _, cred := range creds {
+		tx.Creds = append(tx.Creds, &fxs.FxCredential{Verifiable: cred})
+	}
+	signedBytes, err := cdc.Marshal(avm_txs.CodecVersion, tx)
+	if err != nil {
+		return ids.Empty, 0, err
+	}
+	tx.Initialize(unsignedBytes, signedBytes)
+
+	if err := tx.SyntacticVerify(&snow.Context{
+		NetworkID:   xc.networkID,
+		ChainID:     xc.xChainID,
+		AVAXAssetID: xc.assetID,
+	}, cdc, xc.assetID, txFee, txFee, xNumFxs); err != nil {
+		return ids.Empty, 0, err
+	}
+
+	txID, err = xc.cli.IssueTx(ctx, tx.Bytes())
+	if err != nil {
+		return ids.Empty, 0, fmt.Errorf("failed to issue tx: %w", err)
+	}
+
+	zap.L().Info("polling X-Chain tx", zap.String("txId", txID.String()))
+	var status choices.Status
+	took, err = xc.poller.Poll(ctx, func() (done bool, err error) {
+		status, err = xc.cli.GetTxStatus(ctx, txID)
+		if err != nil {
+			return false, err
+		}
+		return status.Decided(), nil
+	})
+	if err == nil && status != choices.Accepted {
+		err = fmt.Errorf("%w (status %s)", ErrTxNotAccepted, status)
+	}
+	return txID, took, err
+}
+
+// spendAVAX consumes unlocked AVAX [utxos] of [k] until [toBurn] is
+// covered, returning any remainder to [changeOwner].
+//
+// ref. "wallet/chain/x.builder.spend".
+func spendAVAX(
+	k key.Key,
+	utxos []*avax.UTXO,
+	assetID ids.ID,
+	toBurn uint64,
+	changeOwner *secp256k1fx.OutputOwners,
+) (
+	ins []*avax.TransferableInput,
+	changeOuts []*avax.TransferableOutput,
+	signers [][]ids.ShortID,
+	err error,
+) {
+	now := uint64(time.Now().Unix())
+	burned := uint64(0)
+	for _, utxo := range utxos {
+		if burned >= toBurn {
+			break
+		}
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		if _, ok := utxo.Out.(*secp256k1fx.TransferOutput); !ok {
+			continue
+		}
+		_, inputs, inputSigners := k.Spends([]*avax.UTXO{utxo}, key.WithTime(now))
+		if len(inputs) == 0 {
+			// cannot spend this UTXO, skip to try next one
+			continue
+		}
+		in := inputs[0]
+
+		remainingValue := in.In.Amount()
+		amountToBurn := math.Min64(toBurn-burned, remainingValue)
+		burned += amountToBurn
+		remainingValue -= amountToBurn
+		if remainingValue > 0 {
+			changeOuts = append(changeOuts, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          remainingValue,
+					OutputOwners: *changeOwner,
+				},
+			})
+		}
+		ins = append(ins, in)
+		signers = append(signers, inputSigners...)
+	}
+	if burned < toBurn {
+		return nil, nil, nil, fmt.Errorf("%w (have %d, expected %d)", ErrInsufficientBalance, burned, toBurn)
+	}
+	key.SortTransferableInputsWithSigners(ins, signers)
+	return ins, changeOuts, signers, nil
+}
+
+// spendAtomicAVAX consumes all AVAX atomic [utxos] that [k] can spend.
+func spendAtomicAVAX(k key.Key, utxos []*avax.UTXO, assetID ids.ID) (
+	total uint64,
+	ins []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+) {
+	avaxUTXOs := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			continue
+		}
+		if _, ok := utxo.Out.(*secp256k1fx.TransferOutput); !ok {
+			// can't import an unknown transfer output type
+			continue
+		}
+		avaxUTXOs = append(avaxUTXOs, utxo)
+	}
+	return k.Spends(avaxUTXOs, key.WithTime(uint64(time.Now().Unix())))
+}
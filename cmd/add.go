@@ -21,10 +21,14 @@ func AddCommand() *cobra.Command {
 	cmd.AddCommand(
 		newAddValidatorCommand(),
 		newAddSubnetValidatorCommand(),
+		newAddDelegatorCommand(),
+		newAddPermissionlessValidatorCommand(),
+		newAddPermissionlessDelegatorCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
 	return cmd
 }
 
@@ -47,12 +51,10 @@ func CreateAddTable(i *Info) string {
 		validateRewardFeePercent := humanize.FormatFloat("#,###.###", float64(i.validateRewardFeePercent))
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE REWARD FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} %%", validateRewardFeePercent)})
 	}
-	if i.rewardAddr != ids.ShortEmpty {
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}REWARD ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.rewardAddr)})
-	}
-	if i.changeAddr != ids.ShortEmpty {
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHANGE ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.changeAddr)})
+	if i.proofOfPossession != nil {
+		tb.Append([]string{formatter.F("{{magenta}}BLS PUBLIC KEY{{/}}"), formatter.F("{{light-gray}}{{bold}}0x%x{{/}}", i.proofOfPossession.PublicKey)})
 	}
+	i.AppendOwners(tb)
 	tb.Render()
 	return buf.String()
 }
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/dustin/go-humanize"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+func newAddDelegatorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator",
+		Short: "Delegates stake to a primary network validator",
+		Long: `
+Delegates stake to a primary network validator.
+
+$ subnet-cli add delegator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--stake-amount=25000000000
+
+`,
+		RunE: createDelegatorFunc,
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
+	cmd.PersistentFlags().Uint64Var(&stakeAmount, "stake-amount", 0, "stake amount denominated in nano AVAX to delegate to each validator (0 to stake the network minimum, 25 AVAX on Mainnet and 1 AVAX on Fuji)")
+	cmd.PersistentFlags().StringVar(&delegateEnds, "delegate-end", "", "delegate end timestamp in RFC3339 format (default to the validator's end)")
+	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "node address to send rewards to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&rewardAddresses, "reward-addresses", nil, "a list of addresses to send rewards to (instead of --reward-address)")
+	cmd.PersistentFlags().Uint32Var(&rewardThreshold, "reward-threshold", 1, "number of reward addresses required to spend rewards")
+	cmd.PersistentFlags().StringVar(&rewardLocktimes, "reward-locktime", "", "timestamp in RFC3339 format until which rewards are locked")
+	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "node address to send changes to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&changeAddresses, "change-addresses", nil, "a list of addresses to send changes and unlocked stake to (instead of --change-address)")
+	cmd.PersistentFlags().Uint32Var(&changeThreshold, "change-threshold", 1, "number of change addresses required to spend changes")
+	cmd.PersistentFlags().StringVar(&changeLocktimes, "change-locktime", "", "timestamp in RFC3339 format until which changes are locked")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
+
+	return cmd
+}
+
+func createDelegatorFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	info.stakeAmount = stakeAmount
+
+	// current and pending primary network validators can be delegated to,
+	// from the start of their validation
+	info.subnetID = ids.Empty
+	if err := ParseNodeIDs(cli, info, false); err != nil {
+		return err
+	}
+	if len(info.nodeIDs) == 0 {
+		color.Outf("{{magenta}}no primary network validators to delegate to{{/}}\n")
+		return nil
+	}
+	if delegateEnds != "" {
+		info.validateEnd, err = time.Parse(time.RFC3339, delegateEnds)
+		if err != nil {
+			return err
+		}
+	}
+	for _, nodeID := range info.nodeIDs {
+		valInfo := info.valInfos[nodeID]
+		if info.stakeAmount > valInfo.capacity {
+			return fmt.Errorf("%w: %s (stake amount %d expected <=%d)", client.ErrDelegationCapacityExceeded, nodeID, info.stakeAmount, valInfo.capacity)
+		}
+		if !info.validateEnd.IsZero() && info.validateEnd.After(valInfo.end) {
+			return fmt.Errorf("%w: %s (delegate end %v expected <%v)", client.ErrInvalidDelegatePeriod, nodeID, info.validateEnd, valInfo.end)
+		}
+	}
+
+	info.rewardOwner, err = info.ParseOwner(rewardAddrs, rewardAddresses, rewardThreshold, rewardLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (reward owner)", err)
+	}
+	info.changeOwner, err = info.ParseOwner(changeAddrs, changeAddresses, changeThreshold, changeLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (change owner)", err)
+	}
+	info.txFee = uint64(info.feeData.AddPrimaryNetworkDelegatorFee) * uint64(len(info.nodeIDs))
+	info.totalStakeAmount = info.stakeAmount * uint64(len(info.nodeIDs))
+	info.requiredBalance = info.totalStakeAmount + info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.SelectInputs(cli, info.txFee, info.totalStakeAmount, selectOpts); err != nil {
+		return err
+	}
+	msg := CreateDelegateTable(info)
+	if enablePrompt && !dryRun {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to add delegator, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt && !dryRun {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's delegate! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	println()
+	println()
+	println()
+	opts := []client.OpOption{
+		client.WithStakeAmount(info.stakeAmount),
+		client.WithRewardOwner(info.rewardOwner),
+		client.WithChangeOwner(info.changeOwner),
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
+	for _, nodeID := range info.nodeIDs {
+		end := info.validateEnd
+		if end.IsZero() {
+			end = info.valInfos[nodeID].end
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddDelegator(
+			ctx,
+			info.key,
+			nodeID,
+			info.ValidateStart(nodeID),
+			end,
+			opts...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			if err := PrintDryRunTx(pTx); err != nil {
+				return err
+			}
+			continue
+		}
+		color.Outf("{{magenta}}delegated to %s on primary network{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, took)
+	}
+	if dryRun {
+		return nil
+	}
+	info.requiredBalance = 0
+	info.stakeAmount = 0
+	info.totalStakeAmount = 0
+	info.txFee = 0
+	info.inputs = nil
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreateDelegateTable(info))
+	return nil
+}
+
+func CreateDelegateTable(i *Info) string {
+	buf, tb := BaseTableSetup(i)
+	for _, nodeID := range i.nodeIDs {
+		valInfo := i.valInfos[nodeID]
+		capacity := float64(valInfo.capacity) / float64(units.Avax)
+		capacitys := humanize.FormatFloat("#,###.###", capacity)
+		tb.Append([]string{formatter.F("{{orange}}VALIDATOR{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", nodeID)})
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", valInfo.end.Format(time.RFC3339))})
+		tb.Append([]string{formatter.F("{{magenta}}DELEGATION CAPACITY{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} $AVAX", capacitys)})
+	}
+	if !i.validateEnd.IsZero() {
+		tb.Append([]string{formatter.F("{{magenta}}DELEGATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateEnd.Format(time.RFC3339))})
+	}
+	i.AppendOwners(tb)
+	tb.Render()
+	return buf.String()
+}
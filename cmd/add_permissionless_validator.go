@@ -0,0 +1,238 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/dustin/go-humanize"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newAddPermissionlessValidatorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "permissionless-validator",
+		Short: "Adds a validator to a permissionless subnet",
+		Long: `
+Adds a primary network validator to a permissionless (elastic) subnet,
+staking the asset of the subnet.
+
+$ subnet-cli add permissionless-validator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--stake-amount=2000000000000
+
+`,
+		RunE: createPermissionlessValidatorFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
+	cmd.PersistentFlags().Uint64Var(&stakeAmount, "stake-amount", 0, "amount of the subnet asset to stake for each validator")
+	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", "", "validate end timestamp in RFC3339 format (default to the primary network validator's end)")
+	cmd.PersistentFlags().Uint32Var(&validateRewardFeePercent, "validate-reward-fee-percent", defaultValFeePercent, "percentage of fee that the validator will take rewards from its delegators")
+	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "node address to send rewards to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&rewardAddresses, "reward-addresses", nil, "a list of addresses to send rewards to (instead of --reward-address)")
+	cmd.PersistentFlags().Uint32Var(&rewardThreshold, "reward-threshold", 1, "number of reward addresses required to spend rewards")
+	cmd.PersistentFlags().StringVar(&rewardLocktimes, "reward-locktime", "", "timestamp in RFC3339 format until which rewards are locked")
+	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "node address to send changes to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&changeAddresses, "change-addresses", nil, "a list of addresses to send changes and unlocked stake to (instead of --change-address)")
+	cmd.PersistentFlags().Uint32Var(&changeThreshold, "change-threshold", 1, "number of change addresses required to spend changes")
+	cmd.PersistentFlags().StringVar(&changeLocktimes, "change-locktime", "", "timestamp in RFC3339 format until which changes are locked")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
+
+	return cmd
+}
+
+func createPermissionlessValidatorFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	if err := info.ParseStakeAsset(cli); err != nil {
+		return err
+	}
+	if err := ParseNodeIDs(cli, info, true); err != nil {
+		return err
+	}
+	if len(info.nodeIDs) == 0 {
+		color.Outf("{{magenta}}no permissionless validators to add{{/}}\n")
+		return nil
+	}
+	if validateEnds != "" {
+		info.validateEnd, err = time.Parse(time.RFC3339, validateEnds)
+		if err != nil {
+			return err
+		}
+	}
+	info.validateRewardFeePercent = validateRewardFeePercent
+
+	info.rewardOwner, err = info.ParseOwner(rewardAddrs, rewardAddresses, rewardThreshold, rewardLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (reward owner)", err)
+	}
+	info.changeOwner, err = info.ParseOwner(changeAddrs, changeAddresses, changeThreshold, changeLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (change owner)", err)
+	}
+	info.txFee = uint64(info.feeData.AddSubnetValidatorFee) * uint64(len(info.nodeIDs))
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	stakeOpts := []client.OpOption{client.WithStakeAssetID(info.stakeAssetID)}
+	stakeOpts = append(stakeOpts, selectOpts...)
+	if err := info.SelectInputs(cli, info.txFee, info.assetStakeAmount*uint64(len(info.nodeIDs)), stakeOpts); err != nil {
+		return err
+	}
+	msg := CreatePermissionlessTable(info)
+	if enablePrompt && !dryRun {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to add permissionless validator, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt && !dryRun {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	println()
+	println()
+	println()
+	opts := []client.OpOption{
+		client.WithStakeAmount(info.assetStakeAmount),
+		client.WithRewardShares(info.validateRewardFeePercent * 10000),
+		client.WithRewardOwner(info.rewardOwner),
+		client.WithChangeOwner(info.changeOwner),
+	}
+	opts = append(opts, stakeOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
+	for _, nodeID := range info.nodeIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		vdr, err := cli.P().GetValidator(ctx, ids.Empty, nodeID)
+		cancel()
+		if err != nil {
+			return err
+		}
+		start := time.Now().Add(30 * time.Second)
+		if vdr.Start.After(start) {
+			// the node is still pending on the primary network
+			start = vdr.Start
+		}
+		end := info.validateEnd
+		if end.IsZero() {
+			end = vdr.End
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddPermissionlessValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			nodeID,
+			start,
+			end,
+			opts...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			if err := PrintDryRunTx(pTx); err != nil {
+				return err
+			}
+			continue
+		}
+		color.Outf("{{magenta}}added %s to permissionless subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, info.subnetID, took)
+	}
+	if dryRun {
+		return nil
+	}
+	WaitValidator(cli, info.nodeIDs, info)
+	info.requiredBalance = 0
+	info.txFee = 0
+	info.inputs = nil
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreatePermissionlessTable(info))
+	return nil
+}
+
+// ParseStakeAsset reads the asset staked on [i.subnetID] and the
+// amount of it to stake from "--stake-amount".
+func (i *Info) ParseStakeAsset(cli client.Client) (err error) {
+	if stakeAmount == 0 {
+		return fmt.Errorf("%w: zero stake amount", client.ErrInvalidValidatorData)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	i.stakeAssetID, err = cli.P().Client().GetStakingAssetID(ctx, i.subnetID)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("%w: unable to get staking asset of subnet %s", err, i.subnetID)
+	}
+	i.assetStakeAmount = stakeAmount
+	return nil
+}
+
+func CreatePermissionlessTable(i *Info) string {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{orange}}NODE IDs{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.nodeIDs)})
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
+	tb.Append([]string{formatter.F("{{blue}}STAKE ASSET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.stakeAssetID)})
+	if i.assetStakeAmount > 0 {
+		tb.Append([]string{formatter.F("{{red}}{{bold}}EACH STAKE AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} of %s", humanize.Comma(int64(i.assetStakeAmount)), i.stakeAssetID)})
+	}
+	if !i.validateEnd.IsZero() {
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE END{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.validateEnd.Format(time.RFC3339))})
+	}
+	if i.validateRewardFeePercent > 0 {
+		validateRewardFeePercent := humanize.FormatFloat("#,###.###", float64(i.validateRewardFeePercent))
+		tb.Append([]string{formatter.F("{{magenta}}VALIDATE REWARD FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} %%", validateRewardFeePercent)})
+	}
+	i.AppendOwners(tb)
+	tb.Render()
+	return buf.String()
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/client"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -35,6 +37,15 @@ $ subnet-cli add subnet-validator \
 --node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
 --validate-weight=1000
 
+To build the transaction for other subnet control keys to sign:
+
+$ subnet-cli add subnet-validator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--export-tx-path=add-subnet-validator.tx
+
 `,
 		RunE: createSubnetValidatorFunc,
 	}
@@ -42,6 +53,10 @@ $ subnet-cli add subnet-validator \
 	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
 	cmd.PersistentFlags().Uint64Var(&validateWeight, "validate-weight", defaultValidateWeight, "validate weight")
+	cmd.PersistentFlags().StringVar(&exportTxPath, "export-tx-path", "", "file path to export the partially signed transaction to, for other subnet control keys to sign (does not issue)")
+	cmd.PersistentFlags().StringSliceVar(&watchAddresses, "address", nil, "a list of P-Chain addresses to build transactions for without their keys (watch-only, requires --export-tx-path)")
+	cmd.PersistentFlags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "a list of control key P-Chain addresses to sign the exported transaction (default to the key owner's, then others in order)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
 
 	return cmd
 }
@@ -49,6 +64,10 @@ $ subnet-cli add subnet-validator \
 var errZeroValidateWeight = errors.New("zero validate weight")
 
 func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
@@ -65,6 +84,21 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 		color.Outf("{{magenta}}no subnet validators to add{{/}}\n")
 		return nil
 	}
+	ptx := new(client.PartialTx)
+	opts, err := PartialTxOpts(ptx)
+	if err != nil {
+		return err
+	}
+	if len(opts) > 0 && len(info.nodeIDs) > 1 {
+		return errExportMultipleTxs
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
 
 	info.validateWeight = validateWeight
 	info.validateRewardFeePercent = 0
@@ -72,21 +106,24 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 		return errZeroValidateWeight
 	}
 
-	info.rewardAddr = ids.ShortEmpty
-	info.changeAddr = ids.ShortEmpty
+	info.rewardOwner = nil
+	info.changeOwner = nil
 
 	info.txFee *= uint64(len(info.nodeIDs))
 	info.requiredBalance = info.txFee
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
 	msg := CreateAddTable(info)
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to add subnet validator, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
+	if enablePrompt && exportTxPath == "" && !dryRun {
 		prompt := promptui.Select{
 			Label:  "\n",
 			Stdout: os.Stdout,
@@ -112,13 +149,17 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 		//
 		// TODO: cleanup
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-		_, end, err := cli.P().GetValidator(ctx, ids.Empty, nodeID)
+		vdr, err := cli.P().GetValidator(ctx, ids.Empty, nodeID)
 		cancel()
 		if err != nil {
 			return err
 		}
 		info.validateStart = time.Now().Add(30 * time.Second)
-		info.validateEnd = end
+		if vdr.Start.After(info.validateStart) {
+			// the node is still pending on the primary network
+			info.validateStart = vdr.Start
+		}
+		info.validateEnd = vdr.End
 		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 		took, err := cli.P().AddSubnetValidator(
 			ctx,
@@ -128,17 +169,31 @@ func createSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 			info.validateStart,
 			info.validateEnd,
 			validateWeight,
+			opts...,
 		)
 		cancel()
 		if err != nil {
 			return err
 		}
+		if exportTxPath != "" {
+			return ExportPartialTx(ptx, exportTxPath)
+		}
+		if dryRun {
+			if err := PrintDryRunTx(pTx); err != nil {
+				return err
+			}
+			continue
+		}
 		color.Outf("{{magenta}}added %s to subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, info.subnetID, took)
 	}
+	if dryRun {
+		return nil
+	}
 	WaitValidator(cli, info.nodeIDs, info)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
@@ -11,9 +11,10 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/key"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -41,6 +42,17 @@ $ subnet-cli add validator \
 --stake-amount=2000000000000 \
 --validate-reward-fee-percent=2
 
+Post-Banff, a validator should register the BLS key of the node with the
+permissionless transaction format, which is used by default once a key
+is given:
+
+$ subnet-cli add validator \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--stake-amount=2000000000000 \
+--signer-key-path=$HOME/.avalanchego/staking/signer.key
+
 `,
 		RunE: createValidatorFunc,
 	}
@@ -52,14 +64,73 @@ $ subnet-cli add validator \
 	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end.Format(time.RFC3339), "validate start timestamp in RFC3339 format")
 	cmd.PersistentFlags().Uint32Var(&validateRewardFeePercent, "validate-reward-fee-percent", defaultValFeePercent, "percentage of fee that the validator will take rewards from its delegators")
 	cmd.PersistentFlags().StringVar(&rewardAddrs, "reward-address", "", "node address to send rewards to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&rewardAddresses, "reward-addresses", nil, "a list of addresses to send rewards to (instead of --reward-address)")
+	cmd.PersistentFlags().Uint32Var(&rewardThreshold, "reward-threshold", 1, "number of reward addresses required to spend rewards")
+	cmd.PersistentFlags().StringVar(&rewardLocktimes, "reward-locktime", "", "timestamp in RFC3339 format until which rewards are locked")
 	cmd.PersistentFlags().StringVar(&changeAddrs, "change-address", "", "node address to send changes to (default to key owner)")
+	cmd.PersistentFlags().StringSliceVar(&changeAddresses, "change-addresses", nil, "a list of addresses to send changes and unlocked stake to (instead of --change-address)")
+	cmd.PersistentFlags().Uint32Var(&changeThreshold, "change-threshold", 1, "number of change addresses required to spend changes")
+	cmd.PersistentFlags().StringVar(&changeLocktimes, "change-locktime", "", "timestamp in RFC3339 format until which changes are locked")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
+	cmd.PersistentFlags().StringVar(&signerKeyPath, "signer-key-path", "", "BLS secret key file path of the node (e.g., staking/signer.key)")
+	cmd.PersistentFlags().StringVar(&blsPublicKey, "bls-public-key", "", "hex-encoded BLS public key of the node (instead of --signer-key-path)")
+	cmd.PersistentFlags().StringVar(&blsProofOfPossession, "bls-proof-of-possession", "", "hex-encoded BLS proof of possession of the node (with --bls-public-key)")
+	cmd.PersistentFlags().StringVar(&txFormat, "tx-format", "", "validator transaction format (legacy or permissionless, default to permissionless if a BLS key is given)")
 
 	return cmd
 }
 
-var errInvalidValidateRewardFeePercent = errors.New("invalid validate reward fee percent")
+const (
+	txFormatLegacy         = "legacy"
+	txFormatPermissionless = "permissionless"
+)
+
+var (
+	errInvalidValidateRewardFeePercent = errors.New("invalid validate reward fee percent")
+	errInvalidTxFormat                 = errors.New("invalid tx format")
+)
+
+// ParseProofOfPossession loads the BLS key of the node to add, from
+// --signer-key-path or --bls-public-key and --bls-proof-of-possession,
+// and checks it against --tx-format.
+func (i *Info) ParseProofOfPossession() (err error) {
+	i.proofOfPossession = nil
+	switch {
+	case signerKeyPath != "" && (blsPublicKey != "" || blsProofOfPossession != ""):
+		return fmt.Errorf("%w: --signer-key-path and --bls-public-key are exclusive", key.ErrInvalidBLSKey)
+	case signerKeyPath != "":
+		i.proofOfPossession, err = key.LoadProofOfPossession(signerKeyPath)
+	case blsPublicKey != "" || blsProofOfPossession != "":
+		i.proofOfPossession, err = key.ParseProofOfPossession(blsPublicKey, blsProofOfPossession)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch txFormat {
+	case "":
+	case txFormatLegacy:
+		if i.proofOfPossession != nil {
+			return fmt.Errorf("%w: legacy validators can't register a BLS key", errInvalidTxFormat)
+		}
+	case txFormatPermissionless:
+		if i.proofOfPossession == nil {
+			return fmt.Errorf("%w: permissionless validators require --signer-key-path or --bls-public-key", errInvalidTxFormat)
+		}
+	default:
+		return fmt.Errorf("%w %q (expected %q or %q)", errInvalidTxFormat, txFormat, txFormatLegacy, txFormatPermissionless)
+	}
+	if i.proofOfPossession != nil && len(i.nodeIDs) > 1 {
+		return fmt.Errorf("%w: a BLS key belongs to a single node, got %d node IDs", errInvalidTxFormat, len(i.nodeIDs))
+	}
+	return nil
+}
 
 func createValidatorFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
@@ -85,33 +156,36 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 		return errInvalidValidateRewardFeePercent
 	}
 
-	if rewardAddrs != "" {
-		info.rewardAddr, err = address.ParseToID(rewardAddrs)
-		if err != nil {
-			return err
-		}
-	} else {
-		info.rewardAddr = info.key.Addresses()[0]
+	info.rewardOwner, err = info.ParseOwner(rewardAddrs, rewardAddresses, rewardThreshold, rewardLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (reward owner)", err)
 	}
-	if changeAddrs != "" {
-		info.changeAddr, err = address.ParseToID(changeAddrs)
-		if err != nil {
-			return err
-		}
-	} else {
-		info.changeAddr = info.key.Addresses()[0]
+	info.changeOwner, err = info.ParseOwner(changeAddrs, changeAddresses, changeThreshold, changeLocktimes)
+	if err != nil {
+		return fmt.Errorf("%w (change owner)", err)
+	}
+	if err := info.ParseProofOfPossession(); err != nil {
+		return err
 	}
-	info.requiredBalance = info.stakeAmount * uint64(len(info.nodeIDs))
+	info.txFee = 0
+	if info.proofOfPossession != nil {
+		info.txFee = uint64(info.feeData.AddPrimaryNetworkValidatorFee)
+	}
+	info.totalStakeAmount = info.stakeAmount * uint64(len(info.nodeIDs))
+	info.requiredBalance = info.txFee + info.totalStakeAmount
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, info.totalStakeAmount, selectOpts); err != nil {
+		return err
+	}
 	msg := CreateAddTable(info)
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to add validator, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		prompt := promptui.Select{
 			Label:  "\n",
 			Stdout: os.Stdout,
@@ -132,33 +206,67 @@ func createValidatorFunc(cmd *cobra.Command, args []string) error {
 	println()
 	println()
 	println()
+	opts := []client.OpOption{
+		client.WithStakeAmount(info.stakeAmount),
+		client.WithRewardShares(info.validateRewardFeePercent * 10000),
+		client.WithRewardOwner(info.rewardOwner),
+		client.WithChangeOwner(info.changeOwner),
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
 	for i, nodeID := range info.nodeIDs {
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 		info.validateStart = time.Now().Add(30 * time.Second)
-		took, err := cli.P().AddValidator(
-			ctx,
-			info.key,
-			nodeID,
-			info.validateStart,
-			info.validateEnd,
-			client.WithStakeAmount(info.stakeAmount),
-			client.WithRewardShares(info.validateRewardFeePercent*10000),
-			client.WithRewardAddress(info.rewardAddr),
-			client.WithChangeAddress(info.changeAddr),
-		)
+		var took time.Duration
+		if info.proofOfPossession != nil {
+			took, err = cli.P().AddPermissionlessValidator(
+				ctx,
+				info.key,
+				ids.Empty,
+				nodeID,
+				info.validateStart,
+				info.validateEnd,
+				append(opts, client.WithProofOfPossession(info.proofOfPossession))...,
+			)
+		} else {
+			took, err = cli.P().AddValidator(
+				ctx,
+				info.key,
+				nodeID,
+				info.validateStart,
+				info.validateEnd,
+				opts...,
+			)
+		}
 		cancel()
 		if err != nil {
 			return err
 		}
-		color.Outf("{{magenta}}added %s to primary network validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, took)
+		if dryRun {
+			if err := PrintDryRunTx(pTx); err != nil {
+				return err
+			}
+		} else {
+			color.Outf("{{magenta}}added %s to primary network validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, took)
+		}
 		if i < len(info.nodeIDs)-1 {
 			info.validateEnd = info.validateEnd.Add(defaultStagger)
 		}
 	}
+	if dryRun {
+		return nil
+	}
 	WaitValidator(cli, info.nodeIDs, info)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
+	info.totalStakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/spec"
+)
+
+// ApplyCommand implements "subnet-cli apply" command.
+func ApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Converges a subnet to its spec file",
+		Long: `
+Reads the subnet spec (YAML or JSON), compares it to the state of the
+P-Chain, and issues only the transactions needed to converge: creating
+the subnet, adding and removing its validators, and creating its
+blockchains.
+
+$ subnet-cli apply \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--spec-path=subnet.yaml
+
+`,
+		RunE: applyFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
+	cmd.PersistentFlags().StringVar(&specPath, "spec-path", "subnet.yaml", "subnet spec file path (YAML or JSON)")
+
+	return cmd
+}
+
+func applyFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
+	s, err := spec.Load(specPath)
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Planning subnet changes...{{/}}\n")
+	plan, err := planSubnet(cli, s, 0, time.Time{}, false)
+	if err != nil {
+		return err
+	}
+	if plan.empty() {
+		fmt.Fprint(formatter.ColorableStdOut, CreatePlanTable(info, plan))
+		if len(plan.drifts) > 0 {
+			color.Outf("{{yellow}}subnet %s differs from the spec in ways no transaction can converge{{/}}\n", s.ID)
+			cmd.SilenceUsage = true
+			return errUnmanagedDrift
+		}
+		color.Outf("{{magenta}}subnet %s matches the spec{{/}}\n", s.ID)
+		return nil
+	}
+	vmGenesis := make(map[string][]byte, len(plan.createBlockchains))
+	for _, bc := range plan.createBlockchains {
+		vmGenesis[bc.Name], err = os.ReadFile(bc.GenesisPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	info.txFee = plan.txFee(info.feeData)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
+	msg := CreatePlanTable(info, plan)
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to apply the subnet spec, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's apply! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	println()
+	println()
+	println()
+	info.subnetID = s.ID
+	if plan.subnet == nil {
+		owners, err := s.Owners()
+		if err != nil {
+			return err
+		}
+		opts := []client.OpOption{
+			client.WithControlKeys(owners.Addrs),
+			client.WithThreshold(owners.Threshold),
+		}
+		opts = append(opts, selectOpts...)
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		info.subnetID, _, err = cli.P().CreateSubnet(ctx, info.key, opts...)
+		cancel()
+		if err != nil {
+			return err
+		}
+		// record the subnet before adding to it, so that
+		// the next apply doesn't create another one
+		if err := spec.SetID(specPath, info.subnetID); err != nil {
+			return fmt.Errorf("%w (set the created subnet %s as the \"id\" of %s before applying again)", err, info.subnetID, specPath)
+		}
+		color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(recorded as the \"id\" of %s){{/}}\n\n", info.subnetID, specPath)
+	}
+
+	for _, nodeID := range plan.removeValidators {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().RemoveSubnetValidator(ctx, info.key, info.subnetID, nodeID, selectOpts...)
+		cancel()
+		if err != nil {
+			return err
+		}
+		color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, info.subnetID, took)
+	}
+
+	added := make([]ids.NodeID, len(plan.addValidators))
+	for idx, vdr := range plan.addValidators {
+		start, end := plan.validatePeriod(vdr)
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		took, err := cli.P().AddSubnetValidator(
+			ctx,
+			info.key,
+			info.subnetID,
+			vdr.NodeID,
+			start,
+			end,
+			vdr.Weight,
+			selectOpts...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		added[idx] = vdr.NodeID
+		color.Outf("{{magenta}}added %s to subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", vdr.NodeID, info.subnetID, took)
+	}
+
+	for _, bc := range plan.createBlockchains {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		blockchainID, took, err := cli.P().CreateBlockchain(
+			ctx,
+			info.key,
+			info.subnetID,
+			bc.Name,
+			bc.VMID,
+			vmGenesis[bc.Name],
+			selectOpts...,
+		)
+		cancel()
+		if err != nil {
+			return err
+		}
+		color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(%s, took %v){{/}}\n\n", blockchainID, bc.Name, took)
+	}
+
+	WaitValidatorRemoval(cli, plan.removeValidators, info)
+	WaitValidator(cli, added, info)
+	color.Outf("{{magenta}}applied %s to subnet %s{{/}}\n", specPath, info.subnetID)
+	if len(plan.drifts) > 0 {
+		color.Outf("{{yellow}}subnet %s still differs from the spec in ways no transaction can converge{{/}}\n", info.subnetID)
+		cmd.SilenceUsage = true
+		return errUnmanagedDrift
+	}
+	return nil
+}
+
+// CreatePlanTable renders the changes of [plan] and the fee to pay for them.
+func CreatePlanTable(i *Info, plan *subnetPlan) string {
+	buf, tb := BaseTableSetup(i)
+	plan.appendTable(tb, i.networkID)
+	tb.Render()
+	return buf.String()
+}
@@ -8,11 +8,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/dustin/go-humanize"
 	"github.com/olekukonko/tablewriter"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -29,8 +40,12 @@ const (
 )
 
 type ValInfo struct {
+	state client.ValidatorState
 	start time.Time
 	end   time.Time
+	// amount that can still be delegated
+	// (only set for primary network validators)
+	capacity uint64
 }
 
 type Info struct {
@@ -47,10 +62,14 @@ type Info struct {
 	key key.Key
 
 	networkName string
+	networkID   uint32
 
 	subnetIDType string
 	subnetID     ids.ID
 
+	controlKeys []ids.ShortID
+	threshold   uint32
+
 	nodeIDs    []ids.NodeID
 	allNodeIDs []ids.NodeID
 	valInfos   map[ids.NodeID]*ValInfo
@@ -60,13 +79,38 @@ type Info struct {
 	vmID          ids.ID
 	vmGenesisPath string
 
+	// asset created on the X-Chain for a permissionless subnet
+	assetID           ids.ID
+	assetName         string
+	assetSymbol       string
+	assetDenomination uint8
+	assetHolders      []*client.AssetHolder
+
+	transformConfig *client.TransformSubnetConfig
+
 	validateStart            time.Time
 	validateEnd              time.Time
 	validateWeight           uint64
 	validateRewardFeePercent uint32
 
-	rewardAddr ids.ShortID
-	changeAddr ids.ShortID
+	// proofOfPossession is the BLS key of a primary network validator
+	// (nil to issue the legacy validator transaction)
+	proofOfPossession *signer.ProofOfPossession
+
+	// stakeAssetID and assetStakeAmount are the asset and the amount of it
+	// staked on a permissionless subnet (stakeAmount is denominated in AVAX)
+	stakeAssetID     ids.ID
+	assetStakeAmount uint64
+
+	rewardOwner *secp256k1fx.OutputOwners
+	changeOwner *secp256k1fx.OutputOwners
+
+	srcChain       string
+	dstChain       string
+	srcBalance     uint64
+	transferAmount uint64
+
+	inputs []*avax.TransferableInput
 }
 
 func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
@@ -89,22 +133,16 @@ func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
 		uri:         uri,
 		feeData:     txFee,
 		networkName: networkName,
+		networkID:   cli.NetworkID(),
 		valInfos:    map[ids.NodeID]*ValInfo{},
 	}
 	if !loadKey {
 		return cli, info, nil
 	}
 
-	if !useLedger {
-		info.key, err = key.LoadSoft(cli.NetworkID(), privKeyPath)
-		if err != nil {
-			return nil, nil, err
-		}
-	} else {
-		info.key, err = key.NewHard(cli.NetworkID())
-		if err != nil {
-			return nil, nil, err
-		}
+	info.key, err = LoadKey(cli.NetworkID(), cli)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	info.balance, err = cli.P().Balance(context.TODO(), info.key)
@@ -114,6 +152,98 @@ func InitClient(uri string, loadKey bool) (client.Client, *Info, error) {
 	return cli, info, nil
 }
 
+// LoadKey loads the soft keys (or mnemonics) from "--private-key-path",
+// connects to the Ledger if "--ledger" is set and to the remote signer
+// at "--remote-signer-uri" if any. Several keys are aggregated into a
+// keychain that signs with all of them. If "--address" is set, it only
+// watches those addresses instead.
+//
+// Ledger addresses are scanned for UTXOs with [cli], or read from the
+// cache of the last scan if [cli] is nil (e.g., to sign offline).
+func LoadKey(networkID uint32, cli client.Client) (key.Key, error) {
+	if len(watchAddresses) > 0 {
+		return loadWatchKey(networkID)
+	}
+	paths := privKeyPaths
+	if (useLedger || remoteSignerURI != "") && len(paths) == 1 && paths[0] == defaultPrivKeyPath {
+		// the default key file is only loaded without a ledger or remote signer
+		paths = nil
+	}
+
+	keys := make([]key.Key, 0, len(paths)+2)
+	if useLedger {
+		hk, err := key.NewHard(networkID, LedgerOpts(cli)...)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, hk)
+	}
+	if remoteSignerURI != "" {
+		rk, err := key.NewRemote(networkID, remoteSignerURI, key.WithAuthToken(os.Getenv(signerTokenEnv)))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, rk)
+	}
+	for _, p := range paths {
+		sk, err := key.LoadSoft(
+			networkID,
+			p,
+			key.WithNumAddresses(mnemonicAddresses),
+			key.WithPassphrase(func() ([]byte, error) { return readPassphrase(false) }),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w (%s)", err, p)
+		}
+		keys = append(keys, sk)
+	}
+	if len(keys) == 1 {
+		return keys[0], nil
+	}
+	return key.NewKeychain(keys...)
+}
+
+const defaultLedgerGapLimit = 20
+
+// LedgerOpts returns the options to derive the addresses of
+// "--ledger-account" until "--ledger-gap-limit" unused ones.
+func LedgerOpts(cli client.Client) []key.HOpOption {
+	opts := []key.HOpOption{key.WithAccount(ledgerAccount)}
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		opts = append(opts, key.WithCacheDir(filepath.Join(cacheDir, "subnet-cli", "ledger")))
+	}
+	if ledgerGapLimit == 0 {
+		return opts
+	}
+	var used func([]ids.ShortID) ([]ids.ShortID, error)
+	if cli != nil {
+		used = func(addrs []ids.ShortID) ([]ids.ShortID, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+			return cli.P().UsedAddresses(ctx, addrs)
+		}
+	}
+	return append(opts, key.WithGapLimit(ledgerGapLimit, used))
+}
+
+var (
+	errWatchOnlyWithKeys = errors.New("can't combine --address with a ledger, remote signer or private key")
+	errWatchOnlyNoExport = errors.New("--address builds unsigned transactions, set --export-tx-path")
+)
+
+// loadWatchKey creates a watch-only key of "--address", whose
+// transactions are exported unsigned for the key holders to sign.
+func loadWatchKey(networkID uint32) (key.Key, error) {
+	if useLedger || remoteSignerURI != "" ||
+		len(privKeyPaths) != 1 || privKeyPaths[0] != defaultPrivKeyPath {
+		return nil, errWatchOnlyWithKeys
+	}
+	if exportTxPath == "" {
+		return nil, errWatchOnlyNoExport
+	}
+	return key.NewWatch(networkID, watchAddresses)
+}
+
 func CreateLogger() error {
 	lcfg := logutil.GetDefaultZapLoggerConfig()
 	lcfg.Level = zap.NewAtomicLevelAt(logutil.ConvertToZapLevel(logLevel))
@@ -127,7 +257,7 @@ func CreateLogger() error {
 
 func (i *Info) CheckBalance() error {
 	if i.balance < i.requiredBalance {
-		color.Outf("{{red}}insufficient funds to perform operation. get more at https://faucet.avax-test.network{{/}}\n")
+		color.Outf("{{red}}insufficient funds to perform operation. get more at https://faucet.avax-test.network or move them from the X/C-Chain with 'subnet-cli transfer'{{/}}\n")
 		return fmt.Errorf("%w: on %s (expected=%d, have=%d)", ErrInsufficientFunds, i.key.P(), i.requiredBalance, i.balance)
 	}
 	return nil
@@ -148,8 +278,11 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 	tb.SetRowLine(true)
 	tb.SetAlignment(tablewriter.ALIGN_LEFT)
 
-	tb.Append([]string{formatter.F("{{cyan}}{{bold}}PRIMARY P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.key.P()[0])})
-	tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL P-CHAIN BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", curPChainDenominatedBalanceP)})
+	// no key is loaded for read-only commands (e.g., "plan")
+	if i.key != nil {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}PRIMARY P-CHAIN ADDRESS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.key.P()[0])})
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL P-CHAIN BALANCE{{/}} "), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", curPChainDenominatedBalanceP)})
+	}
 	if i.txFee > 0 {
 		txFee := float64(i.txFee) / float64(units.Avax)
 		txFees := humanize.FormatFloat("#,###.###", txFee)
@@ -170,12 +303,319 @@ func BaseTableSetup(i *Info) (*bytes.Buffer, *tablewriter.Table) {
 		requiredBalances := humanize.FormatFloat("#,###.###", requiredBalance)
 		tb.Append([]string{formatter.F("{{red}}{{bold}}REQUIRED BALANCE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", requiredBalances)})
 	}
+	if len(i.inputs) > 0 {
+		inputs := make([]string, len(i.inputs))
+		for idx, in := range i.inputs {
+			inputs[idx] = fmt.Sprintf("%s (%s)", in.UTXOID.String(), formatAsset(in.In.Amount(), in.AssetID()))
+		}
+		tb.Append([]string{formatter.F("{{red}}{{bold}}SELECTED INPUTS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(inputs, "\n"))})
+	}
 
 	tb.Append([]string{formatter.F("{{orange}}URI{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.uri)})
 	tb.Append([]string{formatter.F("{{orange}}NETWORK NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.networkName)})
 	return buf, tb
 }
 
+// ParseAddrs parses a list of formatted addresses (e.g., "P-fuji1...")
+// and returns them sorted.
+func ParseAddrs(raddrs []string) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, len(raddrs))
+	for idx, raddr := range raddrs {
+		addr, err := address.ParseToID(raddr)
+		if err != nil {
+			return nil, err
+		}
+		addrs[idx] = addr
+	}
+	ids.SortShortIDs(addrs)
+	return addrs, nil
+}
+
+var errAddressAndAddresses = errors.New("can't set both an address and a list of addresses")
+
+// ParseOwner parses the owner of reward or change outputs from a single
+// address [raddr] or a list of addresses [raddrs] (default to the key),
+// the number of signatures required to spend them, and an optional
+// RFC3339 timestamp until which they are locked.
+func (i *Info) ParseOwner(raddr string, raddrs []string, threshold uint32, rlocktime string) (*secp256k1fx.OutputOwners, error) {
+	switch {
+	case raddr != "" && len(raddrs) > 0:
+		return nil, errAddressAndAddresses
+	case raddr != "":
+		raddrs = []string{raddr}
+	}
+	owner := &secp256k1fx.OutputOwners{Threshold: threshold}
+	if len(raddrs) > 0 {
+		addrs, err := ParseAddrs(raddrs)
+		if err != nil {
+			return nil, err
+		}
+		owner.Addrs = addrs
+	} else {
+		owner.Addrs = []ids.ShortID{i.key.Addresses()[0]}
+	}
+	if rlocktime != "" {
+		locktime, err := time.Parse(time.RFC3339, rlocktime)
+		if err != nil {
+			return nil, err
+		}
+		owner.Locktime = uint64(locktime.Unix())
+	}
+	if err := client.VerifyOwner(owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// AppendOwners appends the reward and change owners, if set, to [tb].
+func (i *Info) AppendOwners(tb *tablewriter.Table) {
+	if i.rewardOwner != nil {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}REWARD OWNER{{/}}"), formatter.F("{{light-gray}}%s{{/}}", FormatOwners(i.networkID, i.rewardOwner))})
+	}
+	if i.changeOwner != nil {
+		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHANGE OWNER{{/}}"), formatter.F("{{light-gray}}%s{{/}}", FormatOwners(i.networkID, i.changeOwner))})
+	}
+}
+
+func (i *Info) FormatAddrs(addrs []ids.ShortID) []string {
+	return FormatAddrs(i.networkID, addrs)
+}
+
+// FormatAddrs formats a list of addresses as P-Chain addresses
+// of the network [networkID].
+func FormatAddrs(networkID uint32, addrs []ids.ShortID) []string {
+	hrp := constants.GetHRP(networkID)
+	faddrs := make([]string, len(addrs))
+	for idx, addr := range addrs {
+		faddr, err := address.Format("P", hrp, addr[:])
+		if err != nil {
+			// should never happen with a valid HRP
+			faddr = addr.String()
+		}
+		faddrs[idx] = faddr
+	}
+	return faddrs
+}
+
+// CoinSelectionOpts returns the options to select the UTXOs to consume
+// as set by "--coin-selection", "--utxo-ids" and "--exclude-utxo-ids".
+func CoinSelectionOpts() ([]client.OpOption, error) {
+	cs, err := client.ParseCoinSelection(coinSelection)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := parseUTXOIDs(utxoIDs)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := parseUTXOIDs(excludedUTXOIDs)
+	if err != nil {
+		return nil, err
+	}
+	return []client.OpOption{
+		client.WithCoinSelection(cs),
+		client.WithUTXOIDs(allowed),
+		client.WithExcludedUTXOIDs(excluded),
+	}, nil
+}
+
+func parseUTXOIDs(rutxoIDs []string) ([]ids.ID, error) {
+	utxoIDs := make([]ids.ID, len(rutxoIDs))
+	for idx, rutxoID := range rutxoIDs {
+		utxoID, err := client.ParseUTXOID(rutxoID)
+		if err != nil {
+			return nil, err
+		}
+		utxoIDs[idx] = utxoID
+	}
+	return utxoIDs, nil
+}
+
+// SelectInputs looks up the inputs that [opts] consume to burn [fee]
+// and stake [stakeAmt], to be shown before asking for confirmation.
+func (i *Info) SelectInputs(cli client.Client, fee uint64, stakeAmt uint64, opts []client.OpOption) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	i.inputs, err = cli.P().Inputs(ctx, i.key, fee, append([]client.OpOption{client.WithStakeAmount(stakeAmt)}, opts...)...)
+	cancel()
+	return err
+}
+
+var errExportMultipleTxs = errors.New("can only export one transaction at a time")
+
+// PartialTxOpts returns the options to build a partially signed transaction
+// into [ptx] if "--export-tx-path" is set.
+func PartialTxOpts(ptx *client.PartialTx) ([]client.OpOption, error) {
+	if exportTxPath == "" {
+		return nil, nil
+	}
+	opts := []client.OpOption{client.WithPartialTx(ptx)}
+	if len(subnetAuthKeys) > 0 {
+		authKeys, err := ParseAddrs(subnetAuthKeys)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithSubnetAuthKeys(authKeys))
+	}
+	return opts, nil
+}
+
+// ExportPartialTx saves [ptx] to [p] and prints
+// the signers required before it can be issued.
+func ExportPartialTx(ptx *client.PartialTx, p string) error {
+	if err := ptx.Save(p); err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}exported partially signed tx to %q{{/}}\n", p)
+	fmt.Fprint(formatter.ColorableStdOut, CreatePartialTxTable(ptx))
+	return nil
+}
+
+func CreatePartialTxTable(ptx *client.PartialTx) string {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+
+	missing := ptx.Missing()
+	signed := make([]ids.ShortID, 0, len(ptx.Sigs))
+	for _, signer := range ptx.Required() {
+		if _, ok := ptx.Sigs[signer]; ok {
+			signed = append(signed, signer)
+		}
+	}
+	tb.Append([]string{formatter.F("{{orange}}TX TYPE{{/}}"), formatter.F("{{light-gray}}{{bold}}%T{{/}}", ptx.Tx.Unsigned)})
+	tb.Append([]string{formatter.F("{{orange}}NETWORK ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}}", ptx.NetworkID)})
+	tb.Append([]string{formatter.F("{{green}}SIGNED{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", FormatAddrs(ptx.NetworkID, signed))})
+	tb.Append([]string{formatter.F("{{red}}MISSING SIGNATURES{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", FormatAddrs(ptx.NetworkID, missing))})
+	tb.Render()
+	return buf.String()
+}
+
+var errDryRunExportTx = errors.New("can't both dry run and export a transaction")
+
+// DryRunOpts returns the options to build, sign and verify
+// a transaction into [pTx] without issuing it if "--dry-run" is set.
+func DryRunOpts(pTx *txs.Tx) ([]client.OpOption, error) {
+	if !dryRun {
+		return nil, nil
+	}
+	if exportTxPath != "" {
+		return nil, errDryRunExportTx
+	}
+	return []client.OpOption{client.WithDryRunTx(pTx)}, nil
+}
+
+// PrintDryRunTx prints what [pTx] would consume and produce
+// if it were issued.
+func PrintDryRunTx(pTx *txs.Tx) error {
+	s, err := client.SummarizeTx(pTx)
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}dry run, tx %q not issued{{/}}\n", s.TxID)
+	buf, tb := TxTableSetup()
+	if err := AppendTxSummary(tb, s); err != nil {
+		return err
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+func TxTableSetup() (*bytes.Buffer, *tablewriter.Table) {
+	buf := bytes.NewBuffer(nil)
+	tb := tablewriter.NewWriter(buf)
+
+	tb.SetAutoWrapText(false)
+	tb.SetColWidth(1500)
+	tb.SetCenterSeparator("*")
+
+	tb.SetRowLine(true)
+	tb.SetAlignment(tablewriter.ALIGN_LEFT)
+	return buf, tb
+}
+
+// AppendTxSummary appends what the transaction of [s] consumes and
+// produces, who signed it and its serialized bytes to [tb].
+func AppendTxSummary(tb *tablewriter.Table, s *client.TxSummary) error {
+	ins := make([]string, len(s.Ins))
+	for idx, in := range s.Ins {
+		ins[idx] = fmt.Sprintf("%s (%s)", in.UTXOID.String(), formatAsset(in.In.Amount(), in.AssetID()))
+	}
+	outs := make([]string, len(s.Outs))
+	for idx, out := range s.Outs {
+		outs[idx] = fmt.Sprintf("%s to %s", formatAsset(out.Out.Amount(), out.AssetID()), formatOutput(s.NetworkID, out))
+	}
+	burnedAssets := make([]ids.ID, 0, len(s.Burned))
+	for assetID := range s.Burned {
+		burnedAssets = append(burnedAssets, assetID)
+	}
+	ids.SortIDs(burnedAssets)
+	burned := make([]string, len(burnedAssets))
+	for idx, assetID := range burnedAssets {
+		burned[idx] = formatAsset(s.Burned[assetID], assetID)
+	}
+	signers := make([]string, len(s.Signers))
+	for idx, credSigners := range s.Signers {
+		signers[idx] = fmt.Sprintf("credential %d: %v", idx, FormatAddrs(s.NetworkID, credSigners))
+	}
+	txHex, err := formatting.Encode(formatting.Hex, s.Bytes)
+	if err != nil {
+		return err
+	}
+	tb.Append([]string{formatter.F("{{orange}}TX ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", s.TxID)})
+	tb.Append([]string{formatter.F("{{orange}}TX TYPE{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", s.TxType)})
+	tb.Append([]string{formatter.F("{{blue}}INPUTS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(ins, "\n"))})
+	tb.Append([]string{formatter.F("{{blue}}OUTPUTS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(outs, "\n"))})
+	tb.Append([]string{formatter.F("{{red}}{{bold}}BURNED{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}}", strings.Join(burned, "\n"))})
+	if len(s.Memo) > 0 {
+		tb.Append([]string{formatter.F("{{blue}}MEMO{{/}}"), formatter.F("{{light-gray}}{{bold}}%q{{/}}", s.Memo)})
+	}
+	tb.Append([]string{formatter.F("{{green}}SIGNERS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(signers, "\n"))})
+	tb.Append([]string{formatter.F("{{orange}}TX HEX{{/}}"), formatter.F("{{light-gray}}%s{{/}}", txHex)})
+	return nil
+}
+
+// formatAsset formats [amount] of [assetID] in its smallest
+// denomination (e.g., nano AVAX).
+func formatAsset(amount uint64, assetID ids.ID) string {
+	return fmt.Sprintf("%s of %s", humanize.Comma(int64(amount)), assetID)
+}
+
+// formatOutput formats the addresses that own [out].
+func formatOutput(networkID uint32, out *avax.TransferableOutput) string {
+	o := out.Out
+	locktime := uint64(0)
+	if lockOut, ok := o.(*stakeable.LockOut); ok {
+		o, locktime = lockOut.TransferableOut, lockOut.Locktime
+	}
+	transferOut, ok := o.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return fmt.Sprintf("%T", o)
+	}
+	owners := FormatOwners(networkID, &transferOut.OutputOwners)
+	if locktime > 0 {
+		owners += fmt.Sprintf(" (locked until %s)", time.Unix(int64(locktime), 0).Format(time.RFC3339))
+	}
+	return owners
+}
+
+// FormatOwners formats [owners] as "[THRESHOLD]-of-[ADDRESSES]".
+func FormatOwners(networkID uint32, owners *secp256k1fx.OutputOwners) string {
+	s := fmt.Sprintf("%d-of-%v", owners.Threshold, FormatAddrs(networkID, owners.Addrs))
+	if owners.Locktime > 0 {
+		s += fmt.Sprintf(" (locked until %s)", time.Unix(int64(owners.Locktime), 0).Format(time.RFC3339))
+	}
+	return s
+}
+
+// ParseNodeIDs selects the nodes of "--node-ids" that are not yet (if [add])
+// or already (if not [add]) current or pending validators of [i.subnetID].
 func ParseNodeIDs(cli client.Client, i *Info, add bool) error {
 	// TODO: make this parsing logic more explicit (+ store per subnetID, not
 	// just whatever was called last)
@@ -188,8 +628,13 @@ func ParseNodeIDs(cli client.Client, i *Info, add bool) error {
 		}
 		i.allNodeIDs[idx] = nodeID
 
-		start, end, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
-		i.valInfos[nodeID] = &ValInfo{start, end}
+		vdr, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
+		valInfo := &ValInfo{}
+		if err == nil {
+			valInfo.state, valInfo.start, valInfo.end = vdr.State, vdr.Start, vdr.End
+			valInfo.capacity = vdr.DelegationCapacity()
+		}
+		i.valInfos[nodeID] = valInfo
 		switch {
 		case add && errors.Is(err, client.ErrValidatorNotFound):
 			i.nodeIDs = append(i.nodeIDs, nodeID)
@@ -200,20 +645,32 @@ func ParseNodeIDs(cli client.Client, i *Info, add bool) error {
 		case err != nil:
 			return err
 		case add:
-			color.Outf("\n{{yellow}}%s is already a validator on %s{{/}}\n", nodeID, i.subnetID)
+			color.Outf("\n{{yellow}}%s is already a %s validator on %s{{/}}\n", nodeID, vdr.State, i.subnetID)
 		}
 	}
 	return nil
 }
 
+// ValidateStart returns a start time for a staker of [nodeID] that is at
+// least 30 seconds from now and no earlier than its validation start.
+func (i *Info) ValidateStart(nodeID ids.NodeID) time.Time {
+	start := time.Now().Add(30 * time.Second)
+	if valInfo, ok := i.valInfos[nodeID]; ok && valInfo.start.After(start) {
+		return valInfo.start
+	}
+	return start
+}
+
+// WaitValidator waits until each node of [nodeIDs] is a current
+// (not pending) validator of [i.subnetID].
 func WaitValidator(cli client.Client, nodeIDs []ids.NodeID, i *Info) {
 	for _, nodeID := range nodeIDs {
 		color.Outf("{{yellow}}waiting for validator %s to start validating %s...(could take a few minutes){{/}}\n", nodeID, i.subnetID)
 		for {
-			start, end, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
-			if err == nil {
+			vdr, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
+			if err == nil && vdr.State == client.ValidatorStateCurrent {
 				if i.subnetID == ids.Empty {
-					i.valInfos[nodeID] = &ValInfo{start, end}
+					i.valInfos[nodeID] = &ValInfo{state: vdr.State, start: vdr.Start, end: vdr.End}
 				}
 				break
 			}
@@ -226,7 +683,7 @@ func WaitValidatorRemoval(cli client.Client, nodeIDs []ids.NodeID, i *Info) {
 	for _, nodeID := range nodeIDs {
 		color.Outf("{{yellow}}waiting for validator %s to stop validating %s...(could take a few minutes){{/}}\n", nodeID, i.subnetID)
 		for {
-			_, _, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
+			_, err := cli.P().GetValidator(context.Background(), i.subnetID, nodeID)
 			if errors.Is(err, client.ErrValidatorNotFound) {
 				break
 			}
@@ -20,10 +20,12 @@ func CreateCommand() *cobra.Command {
 		newCreateSubnetCommand(),
 		newCreateBlockchainCommand(),
 		newCreateVMIDCommand(),
+		newCreateAssetCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
 	return cmd
 }
 
@@ -32,6 +34,10 @@ func MakeCreateTable(i *Info) string {
 	if i.subnetID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}%s{{/}}", i.subnetIDType), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
 	}
+	if len(i.controlKeys) > 0 {
+		tb.Append([]string{formatter.F("{{blue}}CONTROL KEYS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.FormatAddrs(i.controlKeys))})
+		tb.Append([]string{formatter.F("{{blue}}THRESHOLD{{/}}"), formatter.F("{{light-gray}}{{bold}}%d of %d{{/}}", i.threshold, len(i.controlKeys))})
+	}
 	if i.blockchainID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}CREATED BLOCKCHAIN ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.blockchainID)})
 	}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/dustin/go-humanize"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+var (
+	errInvalidAssetHolder = errors.New("invalid asset holder (expected [ADDRESS]=[AMOUNT])")
+	errInvalidExport      = errors.New("invalid export amount")
+)
+
+func newCreateAssetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "asset",
+		Short: "Creates an asset on the X-Chain and moves it to the P-Chain",
+		Long: `
+Creates a fixed-cap asset on the X-Chain, minted to the initial holders
+(default to the key), then exports the amount held by the key to the
+P-Chain and imports it, so it can be staked on a permissionless subnet
+(see "subnet-cli transform subnet").
+
+$ subnet-cli create asset \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--asset-name="My Subnet Token" \
+--asset-symbol=MST \
+--asset-denomination=9 \
+--asset-supply=720000000000000000
+
+$ subnet-cli create asset \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--asset-name="My Subnet Token" \
+--asset-symbol=MST \
+--initial-holders="X-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p=720000000000000000,X-custom1...=1000" \
+--export-amount=480000000000000000
+
+`,
+		RunE: createAssetFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&assetName, "asset-name", "", "name of the asset (letters, numbers and spaces)")
+	cmd.PersistentFlags().StringVar(&assetSymbol, "asset-symbol", "", "symbol of the asset (up to 4 upper case letters)")
+	cmd.PersistentFlags().Uint8Var(&assetDenomination, "asset-denomination", 9, "number of decimal places of the asset")
+	cmd.PersistentFlags().Uint64Var(&assetSupply, "asset-supply", 0, "amount of the asset minted to the key (ignored with --initial-holders)")
+	cmd.PersistentFlags().StringSliceVar(&assetHolders, "initial-holders", nil, "a list of [ADDRESS]=[AMOUNT] to mint the asset to (default to the key)")
+	cmd.PersistentFlags().Uint64Var(&exportAmount, "export-amount", 0, "amount of the asset exported to the P-Chain (default to all minted to the key)")
+
+	return cmd
+}
+
+// ParseAssetHolders parses "--initial-holders", or mints "--asset-supply"
+// to the key if empty.
+func (i *Info) ParseAssetHolders(rholders []string, supply uint64) (err error) {
+	i.assetHolders = nil
+	if len(rholders) == 0 {
+		if supply == 0 {
+			return fmt.Errorf("%w: --asset-supply or --initial-holders required", client.ErrInvalidSupply)
+		}
+		rholders = []string{fmt.Sprintf("%s=%d", i.key.P()[0], supply)}
+	}
+	total := uint64(0)
+	for _, rholder := range rholders {
+		raddr, ramount, ok := strings.Cut(rholder, "=")
+		if !ok {
+			return fmt.Errorf("%w: %q", errInvalidAssetHolder, rholder)
+		}
+		addr, err := address.ParseToID(strings.TrimSpace(raddr))
+		if err != nil {
+			return fmt.Errorf("%w: %q (%v)", errInvalidAssetHolder, rholder, err)
+		}
+		amount, err := strconv.ParseUint(strings.TrimSpace(ramount), 10, 64)
+		if err != nil || amount == 0 {
+			return fmt.Errorf("%w: %q", errInvalidAssetHolder, rholder)
+		}
+		total, err = math.Add64(total, amount)
+		if err != nil {
+			return fmt.Errorf("%w: %v", client.ErrInvalidSupply, err)
+		}
+		i.assetHolders = append(i.assetHolders, &client.AssetHolder{Addr: addr, Amount: amount})
+	}
+	return nil
+}
+
+// keyAssetAmount returns the amount of the asset minted to the key.
+func (i *Info) keyAssetAmount() uint64 {
+	amount := uint64(0)
+	for _, holder := range i.assetHolders {
+		if holder.Addr == i.key.Addresses()[0] {
+			amount += holder.Amount
+		}
+	}
+	return amount
+}
+
+func createAssetFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	info.assetName, info.assetSymbol, info.assetDenomination = assetName, assetSymbol, assetDenomination
+	if err := info.ParseAssetHolders(assetHolders, assetSupply); err != nil {
+		return err
+	}
+	info.transferAmount = exportAmount
+	if info.transferAmount == 0 {
+		info.transferAmount = info.keyAssetAmount()
+	}
+	if info.transferAmount > info.keyAssetAmount() {
+		return fmt.Errorf("%w (expected<=%d minted to the key, got %d)", errInvalidExport, info.keyAssetAmount(), info.transferAmount)
+	}
+
+	// create and export on the X-Chain, import on the P-Chain
+	xFee := uint64(info.feeData.CreateAssetTxFee)
+	info.txFee = xFee
+	if info.transferAmount > 0 {
+		xFee += uint64(info.feeData.TxFee)
+		info.txFee = xFee + uint64(info.feeData.TxFee)
+		info.requiredBalance = uint64(info.feeData.TxFee)
+		if err := info.CheckBalance(); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	info.srcBalance, err = cli.X().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	info.srcChain = "X"
+	if info.srcBalance < xFee {
+		color.Outf("{{red}}insufficient funds on the X-Chain to create the asset{{/}}\n")
+		return fmt.Errorf("%w: on X-Chain (expected>=%d, have=%d)", ErrInsufficientFunds, xFee, info.srcBalance)
+	}
+
+	msg := CreateAssetTable(info)
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to create asset, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's create! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	println()
+	println()
+	println()
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	assetID, took, err := cli.X().CreateAsset(
+		ctx,
+		info.key,
+		info.assetName,
+		info.assetSymbol,
+		info.assetDenomination,
+		info.assetHolders,
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	info.assetID = assetID
+	color.Outf("{{magenta}}created asset{{/}} %q {{light-gray}}(took %v){{/}}\n", assetID, took)
+
+	if info.transferAmount > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		txID, took, err := cli.X().Export(ctx, info.key, constants.PlatformChainID, info.transferAmount, client.WithAssetID(assetID))
+		cancel()
+		if err != nil {
+			return err
+		}
+		color.Outf("{{magenta}}exported %s %s from the X-Chain{{/}} %q {{light-gray}}(took %v){{/}}\n", humanize.Comma(int64(info.transferAmount)), info.assetSymbol, txID, took)
+
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		txID, took, err = cli.P().Import(ctx, info.key, cli.XChainID(), client.WithAssetID(assetID))
+		cancel()
+		if err != nil {
+			color.Outf("{{red}}exported funds are not lost, they are held on the P-Chain shared memory until imported{{/}}\n")
+			return err
+		}
+		color.Outf("{{magenta}}imported to the P-Chain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", txID, took)
+	}
+
+	info.requiredBalance = 0
+	info.txFee = 0
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	info.srcBalance, err = cli.X().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreateAssetTable(info))
+	color.Outf("{{magenta}}set --asset-id=%s to transform a subnet with the asset{{/}}\n", info.assetID)
+	return nil
+}
+
+// CreateAssetTable renders the asset to create on the X-Chain,
+// and the amount of it exported to the P-Chain.
+func CreateAssetTable(i *Info) string {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL X-CHAIN BALANCE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", formatAVAX(i.srcBalance))})
+	if i.assetID != ids.Empty {
+		tb.Append([]string{formatter.F("{{blue}}CREATED ASSET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.assetID)})
+	}
+	tb.Append([]string{formatter.F("{{dark-green}}ASSET NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s (%s){{/}}", i.assetName, i.assetSymbol)})
+	tb.Append([]string{formatter.F("{{dark-green}}ASSET DENOMINATION{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}}", i.assetDenomination)})
+	hrp := constants.GetHRP(i.networkID)
+	holders := make([]string, len(i.assetHolders))
+	for idx, holder := range i.assetHolders {
+		addr, err := address.Format("X", hrp, holder.Addr[:])
+		if err != nil {
+			addr = holder.Addr.String()
+		}
+		holders[idx] = fmt.Sprintf("%s (%s)", addr, humanize.Comma(int64(holder.Amount)))
+	}
+	tb.Append([]string{formatter.F("{{dark-green}}INITIAL HOLDERS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(holders, "\n"))})
+	if i.transferAmount > 0 {
+		tb.Append([]string{formatter.F("{{red}}{{bold}}EXPORT TO P-CHAIN{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} %s", humanize.Comma(int64(i.transferAmount)), i.assetSymbol)})
+	}
+	tb.Render()
+	return buf.String()
+}
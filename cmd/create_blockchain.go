@@ -9,6 +9,8 @@ import (
 	"os"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/client"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -37,11 +39,19 @@ $ subnet-cli create blockchain \
 	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+	cmd.PersistentFlags().StringVar(&exportTxPath, "export-tx-path", "", "file path to export the partially signed transaction to, for other subnet control keys to sign (does not issue)")
+	cmd.PersistentFlags().StringSliceVar(&watchAddresses, "address", nil, "a list of P-Chain addresses to build transactions for without their keys (watch-only, requires --export-tx-path)")
+	cmd.PersistentFlags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "a list of control key P-Chain addresses to sign the exported transaction (default to the key owner's, then others in order)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
 
 	return cmd
 }
 
 func createBlockchainFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
@@ -64,16 +74,31 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
 	info.chainName = chainName
 	info.vmGenesisPath = vmGenesisPath
+	ptx := new(client.PartialTx)
+	opts, err := PartialTxOpts(ptx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
 
 	msg := MakeCreateTable(info)
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to create blockchain resources, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
+	if enablePrompt && exportTxPath == "" && !dryRun {
 		prompt := promptui.Select{
 			Label:  "\n",
 			Stdout: os.Stdout,
@@ -101,17 +126,25 @@ func createBlockchainFunc(cmd *cobra.Command, args []string) error {
 		info.chainName,
 		info.vmID,
 		vmGenesisBytes,
+		opts...,
 	)
 	cancel()
 	if err != nil {
 		return err
 	}
+	if exportTxPath != "" {
+		return ExportPartialTx(ptx, exportTxPath)
+	}
+	if dryRun {
+		return PrintDryRunTx(pTx)
+	}
 	info.blockchainID = blockchainID
 	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
 
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
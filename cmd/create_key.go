@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	"github.com/ava-labs/subnet-cli/internal/key"
@@ -16,28 +17,68 @@ func newCreateKeyCommand() *cobra.Command {
 		Use:   "key [options]",
 		Short: "Generates a private key",
 		Long: `
-Generates a private key.
+Generates a private key, or a BIP39 mnemonic from which the keys are
+derived on the Avalanche path (m/44'/9000'/0'/0/i). Write the mnemonic
+down to restore the key; "--mnemonic-addresses" sets how many addresses
+the other commands derive from it.
 
 $ subnet-cli create key --private-key-path=.insecure.test.key
 
+$ subnet-cli create key --mnemonic --private-key-path=.insecure.test.mnemonic
+
+With "--encrypt", the key file is encrypted with a passphrase (scrypt and
+AES-256-GCM), read from "--passphrase-fd", "$SUBNET_CLI_KEY_PASSPHRASE"
+or a prompt. The other commands detect and decrypt it the same way.
+
+$ subnet-cli create key --encrypt --private-key-path=.test.key.json
+
 `,
 		RunE: createKeyFunc,
 	}
+	cmd.PersistentFlags().BoolVar(&useMnemonic, "mnemonic", false, "generate a BIP39 mnemonic instead of a single private key")
+	cmd.PersistentFlags().BoolVar(&encryptKey, "encrypt", false, "encrypt the key file with a passphrase")
 	return cmd
 }
 
+var errMultipleKeyPaths = errors.New("can only create one key at a time")
+
 func createKeyFunc(cmd *cobra.Command, args []string) error {
+	if len(privKeyPaths) != 1 {
+		return errMultipleKeyPaths
+	}
+	privKeyPath := privKeyPaths[0]
 	if _, err := os.Stat(privKeyPath); err == nil {
 		color.Outf("{{red}}key already found at %q{{/}}\n", privKeyPath)
 		return os.ErrExist
 	}
-	k, err := key.NewSoft(0)
+	var opts []key.SOpOption
+	if useMnemonic {
+		mnemonic, err := key.NewMnemonic()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, key.WithMnemonic(mnemonic), key.WithNumAddresses(mnemonicAddresses))
+	}
+	k, err := key.NewSoft(0, opts...)
 	if err != nil {
 		return err
 	}
-	if err := k.Save(privKeyPath); err != nil {
+	if encryptKey {
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		err = k.SaveEncrypted(privKeyPath, passphrase)
+		if err != nil {
+			return err
+		}
+	} else if err := k.Save(privKeyPath); err != nil {
 		return err
 	}
+	if useMnemonic {
+		color.Outf("{{green}}created a new mnemonic %q{{/}} {{light-gray}}(back it up, it restores all the derived addresses){{/}}\n", privKeyPath)
+		return nil
+	}
 	color.Outf("{{green}}created a new key %q{{/}}\n", privKeyPath)
 	return nil
 }
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/subnet-cli/client"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
@@ -26,20 +28,55 @@ $ subnet-cli create subnet \
 --private-key-path=.insecure.ewoq.key \
 --public-uri=http://localhost:52250
 
+$ subnet-cli create subnet \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--control-keys=P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p,P-custom1... \
+--threshold=2
+
 `,
 		RunE: createSubnetFunc,
 	}
 
+	cmd.PersistentFlags().StringSliceVar(&controlKeys, "control-keys", nil, "a list of P-Chain addresses that control the subnet (default to key owner)")
+	cmd.PersistentFlags().Uint32Var(&threshold, "threshold", 1, "number of control key signatures required to manage the subnet")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
+
 	return cmd
 }
 
 func createSubnetFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
 	}
+	if len(controlKeys) > 0 {
+		info.controlKeys, err = ParseAddrs(controlKeys)
+		if err != nil {
+			return err
+		}
+	} else {
+		info.controlKeys = []ids.ShortID{info.key.Addresses()[0]}
+	}
+	info.threshold = threshold
+	opts := []client.OpOption{
+		client.WithControlKeys(info.controlKeys),
+		client.WithThreshold(info.threshold),
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
+
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	sid, _, err := cli.P().CreateSubnet(ctx, info.key, client.WithDryMode(true))
+	sid, _, err := cli.P().CreateSubnet(ctx, info.key, append(opts, client.WithDryMode(true))...)
 	cancel()
 	if err != nil {
 		return err
@@ -50,14 +87,17 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
 
 	msg := MakeCreateTable(info)
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to create subnet resources, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		prompt := promptui.Select{
 			Label:  "\n",
 			Stdout: os.Stdout,
@@ -79,11 +119,14 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	println()
 	println()
 	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
+	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, opts...)
 	cancel()
 	if err != nil {
 		return err
 	}
+	if dryRun {
+		return PrintDryRunTx(pTx)
+	}
 	info.subnetIDType = "CREATED SUBNET ID"
 	info.subnetID = subnetID
 
@@ -93,6 +136,7 @@ func createSubnetFunc(cmd *cobra.Command, args []string) error {
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
@@ -7,4 +7,7 @@ import (
 	"errors"
 )
 
-var ErrInsufficientFunds = errors.New("insufficient funds")
+var (
+	ErrInsufficientFunds     = errors.New("insufficient funds")
+	ErrInvalidTransferAmount = errors.New("invalid transfer amount")
+)
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// InspectCommand implements "subnet-cli inspect" command.
+func InspectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Sub-commands for inspecting resources",
+	}
+	cmd.AddCommand(
+		newInspectTxCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&outputFormat, "output", "table", "output format ('table' or 'json')")
+	return cmd
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/dustin/go-humanize"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+)
+
+var errInvalidOutputFormat = errors.New("invalid output format")
+
+func newInspectTxCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx [TX ID|HEX|FILE]",
+		Short: "Decodes and prints a P-Chain transaction",
+		Long: `
+Decodes a P-Chain transaction and prints its inputs, outputs,
+burned fee, credentials and signer addresses.
+
+The transaction is fetched from "--public-uri" when given a
+transaction ID, or decoded from hex-encoded or raw bytes when
+given a hex string or a file path.
+
+$ subnet-cli inspect tx 2FUtRwr5ocdVyE3gJpy3wPpujXPLPwKmjhwT8EKKpYpbHqEJXr \
+--public-uri=https://api.avax-test.network
+
+$ subnet-cli inspect tx ./tx.hex --output=json
+
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: inspectTxFunc,
+	}
+	return cmd
+}
+
+func inspectTxFunc(cmd *cobra.Command, args []string) error {
+	if outputFormat != "table" && outputFormat != "json" {
+		return fmt.Errorf("%w %q (expected 'table' or 'json')", errInvalidOutputFormat, outputFormat)
+	}
+	txBytes, err := readTx(args[0])
+	if err != nil {
+		return err
+	}
+	pTx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return err
+	}
+	s, err := client.SummarizeTx(pTx)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		return printTxJSON(pTx, s)
+	}
+	buf, tb := TxTableSetup()
+	if err := appendTxDetails(tb, s.NetworkID, pTx.Unsigned); err != nil {
+		return err
+	}
+	if err := AppendTxSummary(tb, s); err != nil {
+		return err
+	}
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+// readTx reads the bytes of a P-Chain transaction from a file holding
+// hex-encoded or raw bytes, from a hex string, or fetches it by ID.
+func readTx(arg string) ([]byte, error) {
+	if b, err := os.ReadFile(arg); err == nil {
+		if txBytes, err := decodeHex(strings.TrimSpace(string(b))); err == nil {
+			return txBytes, nil
+		}
+		return b, nil
+	}
+	if txID, err := ids.FromString(arg); err == nil {
+		cli, _, err := InitClient(publicURI, false)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		txBytes, err := cli.P().Client().GetTx(ctx, txID)
+		cancel()
+		return txBytes, err
+	}
+	return decodeHex(arg)
+}
+
+// decodeHex decodes [s] with or without its "0x" prefix and checksum.
+func decodeHex(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		s = "0x" + s
+	}
+	if b, err := formatting.Decode(formatting.Hex, s); err == nil {
+		return b, nil
+	}
+	return formatting.Decode(formatting.HexNC, s)
+}
+
+type txJSON struct {
+	TxID    ids.ID            `json:"txID"`
+	TxType  string            `json:"txType"`
+	Tx      *txs.Tx           `json:"tx"`
+	Burned  map[ids.ID]uint64 `json:"burned"`
+	Signers [][]string        `json:"signers"`
+}
+
+func printTxJSON(pTx *txs.Tx, s *client.TxSummary) error {
+	// addresses and chain IDs are only JSON-formatted with a context
+	aliaser := ids.NewAliaser()
+	if err := aliaser.Alias(constants.PlatformChainID, "P"); err != nil {
+		return err
+	}
+	pTx.Unsigned.InitCtx(&snow.Context{
+		NetworkID: s.NetworkID,
+		ChainID:   constants.PlatformChainID,
+		BCLookup:  aliaser,
+	})
+
+	signers := make([][]string, len(s.Signers))
+	for idx, credSigners := range s.Signers {
+		signers[idx] = FormatAddrs(s.NetworkID, credSigners)
+	}
+	b, err := json.MarshalIndent(&txJSON{
+		TxID:    s.TxID,
+		TxType:  s.TxType,
+		Tx:      pTx,
+		Burned:  s.Burned,
+		Signers: signers,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// appendTxDetails appends the fields specific to the type of [utx] to [tb].
+func appendTxDetails(tb *tablewriter.Table, networkID uint32, utx txs.UnsignedTx) error {
+	row := func(k string, format string, args ...interface{}) {
+		tb.Append([]string{formatter.F("{{cyan}}%s{{/}}", k), formatter.F("{{light-gray}}{{bold}}"+format+"{{/}}", args...)})
+	}
+	switch utx := utx.(type) {
+	case *txs.AdvanceTimeTx:
+		row("TIMESTAMP", "%s", utx.Timestamp().Format(time.RFC3339))
+	case *txs.RewardValidatorTx:
+		row("STAKER TX ID", "%s", utx.TxID)
+	case *txs.CreateSubnetTx:
+		row("OWNERS", "%s", formatOwner(networkID, utx.Owner))
+	case *txs.CreateChainTx:
+		fxIDs := make([]string, len(utx.FxIDs))
+		for idx, fxID := range utx.FxIDs {
+			fxIDs[idx] = fxID.String()
+		}
+		row("SUBNET ID", "%s", utx.SubnetID)
+		row("CHAIN NAME", "%s", utx.ChainName)
+		row("VM ID", "%s", utx.VMID)
+		row("FX IDS", "%v", fxIDs)
+		row("GENESIS", "%d bytes", len(utx.GenesisData))
+		row("SUBNET AUTH", "%s", formatSubnetAuth(utx.SubnetAuth))
+	case *txs.AddValidatorTx:
+		appendValidator(row, &utx.Validator, formatAVAX(utx.Validator.Wght)+" $AVAX")
+		row("REWARD OWNERS", "%s", formatOwner(networkID, utx.RewardsOwner))
+		row("DELEGATION SHARES", "%d (%.4f%%)", utx.DelegationShares, float64(utx.DelegationShares)/10000)
+	case *txs.AddDelegatorTx:
+		appendValidator(row, &utx.Validator, formatAVAX(utx.Validator.Wght)+" $AVAX")
+		row("REWARD OWNERS", "%s", formatOwner(networkID, utx.DelegationRewardsOwner))
+	case *txs.AddSubnetValidatorTx:
+		row("SUBNET ID", "%s", utx.Validator.Subnet)
+		// the weight of a subnet validator is not an amount
+		appendValidator(row, &utx.Validator.Validator, humanize.Comma(int64(utx.Validator.Wght)))
+		row("SUBNET AUTH", "%s", formatSubnetAuth(utx.SubnetAuth))
+	case *txs.RemoveSubnetValidatorTx:
+		row("SUBNET ID", "%s", utx.Subnet)
+		row("NODE ID", "%s", utx.NodeID)
+		row("SUBNET AUTH", "%s", formatSubnetAuth(utx.SubnetAuth))
+	case *txs.ExportTx:
+		row("DESTINATION CHAIN", "%s", utx.DestinationChain)
+	case *txs.ImportTx:
+		row("SOURCE CHAIN", "%s", utx.SourceChain)
+	case *txs.TransformSubnetTx:
+		row("SUBNET ID", "%s", utx.Subnet)
+		row("ASSET ID", "%s", utx.AssetID)
+		row("SUPPLY", "%d initial, %d maximum", utx.InitialSupply, utx.MaximumSupply)
+		row("CONSUMPTION RATE", "%d minimum, %d maximum", utx.MinConsumptionRate, utx.MaxConsumptionRate)
+		row("VALIDATOR STAKE", "%d minimum, %d maximum", utx.MinValidatorStake, utx.MaxValidatorStake)
+		row("STAKE DURATION", "%s minimum, %s maximum",
+			time.Duration(utx.MinStakeDuration)*time.Second, time.Duration(utx.MaxStakeDuration)*time.Second)
+		row("MIN DELEGATION FEE", "%d", utx.MinDelegationFee)
+		row("MIN DELEGATOR STAKE", "%d", utx.MinDelegatorStake)
+		row("MAX VALIDATOR WEIGHT FACTOR", "%d", utx.MaxValidatorWeightFactor)
+		row("UPTIME REQUIREMENT", "%d", utx.UptimeRequirement)
+		row("SUBNET AUTH", "%s", formatSubnetAuth(utx.SubnetAuth))
+	case *txs.AddPermissionlessValidatorTx:
+		row("SUBNET ID", "%s", utx.Subnet)
+		appendValidator(row, &utx.Validator, formatStake(utx.Subnet, utx.Validator.Wght, utx.Stake()))
+		if pop, ok := utx.Signer.(*signer.ProofOfPossession); ok {
+			row("BLS PUBLIC KEY", "0x%x", pop.PublicKey[:])
+		}
+		row("VALIDATOR REWARD OWNERS", "%s", formatOwner(networkID, utx.ValidatorRewardsOwner))
+		row("DELEGATOR REWARD OWNERS", "%s", formatOwner(networkID, utx.DelegatorRewardsOwner))
+		row("DELEGATION SHARES", "%d (%.4f%%)", utx.DelegationShares, float64(utx.DelegationShares)/10000)
+	case *txs.AddPermissionlessDelegatorTx:
+		row("SUBNET ID", "%s", utx.Subnet)
+		appendValidator(row, &utx.Validator, formatStake(utx.Subnet, utx.Validator.Wght, utx.Stake()))
+		row("REWARD OWNERS", "%s", formatOwner(networkID, utx.DelegationRewardsOwner))
+	default:
+		return fmt.Errorf("%w: %T", client.ErrWrongTxType, utx)
+	}
+	return nil
+}
+
+// appendValidator appends the fields of [vdr], with its [weight] formatted.
+func appendValidator(row func(string, string, ...interface{}), vdr *validator.Validator, weight string) {
+	row("NODE ID", "%s", vdr.NodeID)
+	row("VALIDATE START", "%s", vdr.StartTime().Format(time.RFC3339))
+	row("VALIDATE END", "%s", vdr.EndTime().Format(time.RFC3339))
+	row("WEIGHT", "%s", weight)
+}
+
+// formatStake formats the [weight] staked on [subnetID], which is AVAX on
+// the primary network and the asset of [stake] on an elastic subnet.
+func formatStake(subnetID ids.ID, weight uint64, stake []*avax.TransferableOutput) string {
+	if subnetID == constants.PrimaryNetworkID || len(stake) == 0 {
+		return formatAVAX(weight) + " $AVAX"
+	}
+	return formatAsset(weight, stake[0].AssetID())
+}
+
+func formatOwner(networkID uint32, owner fx.Owner) string {
+	owners, ok := owner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return fmt.Sprintf("%T", owner)
+	}
+	return FormatOwners(networkID, owners)
+}
+
+func formatSubnetAuth(auth verify.Verifiable) string {
+	in, ok := auth.(*secp256k1fx.Input)
+	if !ok {
+		return fmt.Sprintf("%T", auth)
+	}
+	return fmt.Sprintf("signature indices %v", in.SigIndices)
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+// IssueCommand implements "subnet-cli issue" command.
+func IssueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issues a transaction once all signatures are collected",
+		Long: `
+Combines the signatures of one or more copies of a partially signed
+transaction and issues it once the signature threshold is met.
+
+$ subnet-cli issue \
+--public-uri=http://localhost:52250 \
+--tx-path=add-subnet-validator.1.tx,add-subnet-validator.2.tx
+
+`,
+		RunE: issueFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&txPaths, "tx-path", nil, "a list of partially signed transaction file paths to combine")
+
+	return cmd
+}
+
+var errNetworkMismatch = errors.New("transaction built for a different network")
+
+func issueFunc(cmd *cobra.Command, args []string) error {
+	if len(txPaths) == 0 {
+		return errNoTxPath
+	}
+	ptx, err := client.LoadPartialTx(txPaths[0])
+	if err != nil {
+		return err
+	}
+	for _, p := range txPaths[1:] {
+		other, err := client.LoadPartialTx(p)
+		if err != nil {
+			return err
+		}
+		if err := ptx.Merge(other); err != nil {
+			return fmt.Errorf("%w: %q", err, p)
+		}
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreatePartialTxTable(ptx))
+	pTx, err := ptx.Signed()
+	if err != nil {
+		return err
+	}
+
+	cli, _, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+	if cli.NetworkID() != ptx.NetworkID {
+		return fmt.Errorf("%w (expected %d, got %d)", errNetworkMismatch, cli.NetworkID(), ptx.NetworkID)
+	}
+
+	if enablePrompt {
+		color.Outf("\n{{blue}}{{bold}}Ready to issue %T, should we continue?{{/}}\n", ptx.Tx.Unsigned)
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's issue!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	txID, took, err := cli.P().Issue(ctx, pTx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	color.Outf("{{magenta}}issued tx{{/}} %q {{light-gray}}(took %v){{/}}\n", txID, took)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+)
+
+const defaultPassphraseEnv = "SUBNET_CLI_KEY_PASSPHRASE"
+
+var errPassphraseMismatch = errors.New("passphrases do not match")
+
+// readPassphrase returns the passphrase of an encrypted key file, read
+// from "--passphrase-fd", the "--passphrase-env" environment variable,
+// or a prompt (twice if [confirm]), in that order.
+func readPassphrase(confirm bool) ([]byte, error) {
+	if passphraseFD >= 0 {
+		f := os.NewFile(uintptr(passphraseFD), "passphrase")
+		if f == nil {
+			return nil, fmt.Errorf("%w: invalid file descriptor %d", key.ErrMissingPassphrase, passphraseFD)
+		}
+		line, err := bufio.NewReader(f).ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("%w: %v", key.ErrMissingPassphrase, err)
+		}
+		return []byte(strings.TrimRight(line, "\r\n")), nil
+	}
+	if passphraseEnv != "" {
+		if v := os.Getenv(passphraseEnv); v != "" {
+			return []byte(v), nil
+		}
+	}
+	if !enablePrompt {
+		return nil, fmt.Errorf("%w: set --passphrase-fd or $%s", key.ErrMissingPassphrase, passphraseEnv)
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Key passphrase",
+		Mask:  '*',
+	}
+	passphrase, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+	if confirm {
+		prompt.Label = "Confirm key passphrase"
+		again, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+		if again != passphrase {
+			return nil, errPassphraseMismatch
+		}
+	}
+	return []byte(passphrase), nil
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/ava-labs/subnet-cli/pkg/spec"
+)
+
+var errChangesPending = errors.New("changes pending")
+
+// PlanCommand implements "subnet-cli plan" command.
+func PlanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Shows the changes needed to converge a subnet, without a key",
+		Long: `
+Compares the desired state of a subnet, from a spec file or from the
+flags of the other commands (which only add validators and blockchains),
+to the state of the P-Chain. Prints the
+validators to add and remove, the blockchains to create, and the fee
+and stake required. Exits non-zero if any change is pending or if the
+subnet drifted in ways no transaction can converge.
+
+$ subnet-cli plan \
+--public-uri=http://localhost:52250 \
+--spec-path=subnet.yaml
+
+$ subnet-cli plan \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--node-ids="NodeID-4B4rc5vdD1758JSBYL1xyvE5NHGzz6xzH" \
+--chain-name=my-custom-chain \
+--vm-id=tGas3T58KzdjLHhBDMnH2TvrddhqTji5iZAMZ3RXs2NLpSnhH \
+--vm-genesis-path=.my-custom-vm.genesis
+
+`,
+		RunE: planFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringVar(&specPath, "spec-path", "", "subnet spec file path (YAML or JSON), overrides the flags below")
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID, empty to create the subnet)")
+	cmd.PersistentFlags().StringSliceVar(&controlKeys, "control-keys", nil, "a list of P-Chain addresses that control the subnet (default to the subnet's)")
+	cmd.PersistentFlags().Uint32Var(&threshold, "threshold", 1, "number of control key signatures required to manage the subnet")
+	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs that validate the subnet (must be formatted in ids.ID)")
+	cmd.PersistentFlags().Uint64Var(&validateWeight, "validate-weight", defaultValidateWeight, "validate weight")
+	cmd.PersistentFlags().Uint64Var(&stakeAmount, "stake-amount", 0, "stake amount denominated in nano AVAX for the nodes not on the primary network yet (0 to require them to be)")
+	end := time.Now().Add(defaultValDuration)
+	cmd.PersistentFlags().StringVar(&validateEnds, "validate-end", end.Format(time.RFC3339), "validate end timestamp in RFC3339 format of the nodes not on the primary network yet")
+	cmd.PersistentFlags().StringVar(&chainName, "chain-name", "", "chain name")
+	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
+
+	return cmd
+}
+
+func planFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(publicURI, false)
+	if err != nil {
+		return err
+	}
+
+	var s *spec.Subnet
+	if specPath != "" {
+		s, err = spec.Load(specPath)
+	} else {
+		s, err = specFromFlags(cli, info)
+	}
+	if err != nil {
+		return err
+	}
+	primaryEnd, err := time.Parse(time.RFC3339, validateEnds)
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Planning subnet changes...{{/}}\n")
+	// the flags only name the validators and blockchains to add
+	plan, err := planSubnet(cli, s, stakeAmount, primaryEnd, specPath == "")
+	if err != nil {
+		return err
+	}
+	info.txFee = plan.txFee(info.feeData)
+	if len(plan.addPrimaryValidators) > 0 {
+		info.stakeAmount = plan.stakeAmount
+		info.totalStakeAmount = plan.totalStake()
+	}
+	info.requiredBalance = info.txFee + info.totalStakeAmount
+	fmt.Fprint(formatter.ColorableStdOut, CreatePlanTable(info, plan))
+
+	if !plan.empty() {
+		color.Outf("{{yellow}}changes pending, run 'subnet-cli apply' or the matching commands to converge{{/}}\n")
+		// not a usage error, exit non-zero so CI can gate on it
+		cmd.SilenceUsage = true
+		return errChangesPending
+	}
+	if len(plan.drifts) > 0 {
+		color.Outf("{{yellow}}subnet %s differs from the desired state in ways no transaction can converge{{/}}\n", s.ID)
+		cmd.SilenceUsage = true
+		return errUnmanagedDrift
+	}
+	color.Outf("{{magenta}}subnet %s matches the desired state{{/}}\n", s.ID)
+	return nil
+}
+
+// specFromFlags builds the desired state of a subnet from the flags
+// of "create subnet", "add subnet-validator" and "create blockchain".
+func specFromFlags(cli client.Client, i *Info) (*spec.Subnet, error) {
+	s := &spec.Subnet{
+		RawID:       subnetIDs,
+		ControlKeys: controlKeys,
+		Threshold:   threshold,
+	}
+	if len(s.ControlKeys) == 0 && s.RawID != "" {
+		subnetID, err := ids.FromString(s.RawID)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnet, err := cli.P().GetSubnet(ctx, subnetID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("%w (%s)", err, subnetID)
+		}
+		s.ControlKeys = FormatAddrs(i.networkID, subnet.Owners.Addrs)
+		s.Threshold = subnet.Owners.Threshold
+	}
+	for _, nodeID := range nodeIDs {
+		s.Validators = append(s.Validators, &spec.Validator{
+			RawNodeID: nodeID,
+			Weight:    validateWeight,
+		})
+	}
+	if chainName != "" {
+		s.Blockchains = append(s.Blockchains, &spec.Blockchain{
+			Name:        chainName,
+			RawVMID:     vmIDs,
+			GenesisPath: vmGenesisPath,
+		})
+	}
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -19,8 +19,9 @@ func RemoveCommand() *cobra.Command {
 		newRemoveSubnetValidatorCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
 	return cmd
 }
 
@@ -30,12 +31,7 @@ func CreateRemoveValidator(i *Info) string {
 	if i.subnetID != ids.Empty {
 		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
 	}
-	if i.rewardAddr != ids.ShortEmpty {
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}REWARD ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.rewardAddr)})
-	}
-	if i.changeAddr != ids.ShortEmpty {
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHANGE ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.changeAddr)})
-	}
+	i.AppendOwners(tb)
 	tb.Render()
 	return buf.String()
 }
@@ -9,6 +9,8 @@ import (
 	"os"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/client"
 	"github.com/ava-labs/subnet-cli/pkg/color"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -33,11 +35,19 @@ $ subnet-cli remove subnet-validator \
 
 	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&exportTxPath, "export-tx-path", "", "file path to export the partially signed transaction to, for other subnet control keys to sign (does not issue)")
+	cmd.PersistentFlags().StringSliceVar(&watchAddresses, "address", nil, "a list of P-Chain addresses to build transactions for without their keys (watch-only, requires --export-tx-path)")
+	cmd.PersistentFlags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "a list of control key P-Chain addresses to sign the exported transaction (default to the key owner's, then others in order)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
 
 	return cmd
 }
 
 func removeSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
@@ -54,18 +64,36 @@ func removeSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 		color.Outf("{{magenta}}no subnet validators to add{{/}}\n")
 		return nil
 	}
+	ptx := new(client.PartialTx)
+	opts, err := PartialTxOpts(ptx)
+	if err != nil {
+		return err
+	}
+	if len(opts) > 0 && len(info.nodeIDs) > 1 {
+		return errExportMultipleTxs
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
 	info.txFee *= uint64(len(info.nodeIDs))
 	info.requiredBalance = info.txFee
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
 	msg := CreateRemoveValidator(info)
-	if enablePrompt {
+	if enablePrompt && !dryRun {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to remove subnet validator, should we continue?{{/}}\n") + msg
 	}
 	fmt.Fprint(formatter.ColorableStdOut, msg)
 
-	if enablePrompt {
+	if enablePrompt && exportTxPath == "" && !dryRun {
 		prompt := promptui.Select{
 			Label:  "\n",
 			Stdout: os.Stdout,
@@ -96,17 +124,31 @@ func removeSubnetValidatorFunc(cmd *cobra.Command, args []string) error {
 			info.key,
 			info.subnetID,
 			nodeID,
+			opts...,
 		)
 		cancel()
 		if err != nil {
 			return err
 		}
+		if exportTxPath != "" {
+			return ExportPartialTx(ptx, exportTxPath)
+		}
+		if dryRun {
+			if err := PrintDryRunTx(pTx); err != nil {
+				return err
+			}
+			continue
+		}
 		color.Outf("{{magenta}}removed %s from subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, info.subnetID, took)
 	}
+	if dryRun {
+		return nil
+	}
 	WaitValidatorRemoval(cli, info.nodeIDs, info)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
@@ -18,12 +18,23 @@ var rootCmd = &cobra.Command{
 	SuggestFor: []string{"subnet-cli", "subnetcli", "subnetctl"},
 }
 
+const defaultPrivKeyPath = ".subnet-cli.pk"
+
 var (
 	enablePrompt bool
 	logLevel     string
 
-	privKeyPath string
-	useLedger   bool
+	privKeyPaths      []string
+	useLedger         bool
+	ledgerAccount     uint32
+	ledgerGapLimit    uint32
+	remoteSignerURI   string
+	watchAddresses    []string
+	useMnemonic       bool
+	mnemonicAddresses uint32
+	encryptKey        bool
+	passphraseEnv     string
+	passphraseFD      int
 
 	privateURI string
 	publicURI  string
@@ -32,22 +43,72 @@ var (
 	requestTimeout time.Duration
 
 	subnetIDs   string
+	controlKeys []string
+	threshold   uint32
 	nodeIDs     []string
 	stakeAmount uint64
 
 	validateEnds             string
+	delegateEnds             string
 	validateWeight           uint64
 	validateRewardFeePercent uint32
 
-	rewardAddrs string
-	changeAddrs string
+	signerKeyPath        string
+	blsPublicKey         string
+	blsProofOfPossession string
+	txFormat             string
+
+	rewardAddrs     string
+	rewardAddresses []string
+	rewardThreshold uint32
+	rewardLocktimes string
+	changeAddrs     string
+	changeAddresses []string
+	changeThreshold uint32
+	changeLocktimes string
 
 	chainName     string
 	vmIDs         string
 	vmGenesisPath string
+	journalPath   string
+	specPath      string
+
+	assetName         string
+	assetSymbol       string
+	assetDenomination uint8
+	assetSupply       uint64
+	assetHolders      []string
+	exportAmount      uint64
+
+	assetIDs                  string
+	initialSupply             uint64
+	maximumSupply             uint64
+	minConsumptionRatePercent uint64
+	maxConsumptionRatePercent uint64
+	minValidatorStake         uint64
+	maxValidatorStake         uint64
+	minStakeDuration          time.Duration
+	maxStakeDuration          time.Duration
+	minDelegationFeePercent   uint32
+	minDelegatorStake         uint64
+	maxValidatorWeightFactor  uint8
+	uptimeRequirementPercent  uint32
 
 	blockchainID      string
 	checkBootstrapped bool
+
+	exportTxPath   string
+	subnetAuthKeys []string
+	txPath         string
+	txPaths        []string
+
+	transferAmount uint64
+	dryRun         bool
+	outputFormat   string
+
+	coinSelection   string
+	utxoIDs         []string
+	excludedUTXOIDs []string
 )
 
 func init() {
@@ -59,12 +120,28 @@ func init() {
 		RemoveCommand(),
 		StatusCommand(),
 		WizardCommand(),
+		SignCommand(),
+		IssueCommand(),
+		TransferCommand(),
+		InspectCommand(),
+		ApplyCommand(),
+		PlanCommand(),
+		TransformCommand(),
+		SignerCommand(),
 	)
 
 	rootCmd.PersistentFlags().BoolVar(&enablePrompt, "enable-prompt", true, "'true' to enable prompt mode")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", logutil.DefaultLogLevel, "log level")
 	rootCmd.PersistentFlags().DurationVar(&pollInterval, "poll-interval", time.Second, "interval to poll tx/blockchain status")
 	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 2*time.Minute, "request timeout")
+	rootCmd.PersistentFlags().Uint32Var(&ledgerAccount, "ledger-account", 0, "ledger account to use the addresses of (m/44'/9000'/[ACCOUNT]'/0/i)")
+	rootCmd.PersistentFlags().Uint32Var(&ledgerGapLimit, "ledger-gap-limit", defaultLedgerGapLimit, "number of consecutive unused ledger addresses to stop deriving at (0 to derive the first 1024)")
+	rootCmd.PersistentFlags().Uint32Var(&mnemonicAddresses, "mnemonic-addresses", 1, "number of addresses derived from a mnemonic key file")
+	rootCmd.PersistentFlags().StringVar(&passphraseEnv, "passphrase-env", defaultPassphraseEnv, "environment variable holding the passphrase of an encrypted key file")
+	rootCmd.PersistentFlags().IntVar(&passphraseFD, "passphrase-fd", -1, "file descriptor to read the passphrase of an encrypted key file from (e.g., 3 with '3<passphrase.txt')")
+	rootCmd.PersistentFlags().StringVar(&coinSelection, "coin-selection", "", "order to consume UTXOs in (largest-first, smallest-first or minimize-inputs, default to API order)")
+	rootCmd.PersistentFlags().StringSliceVar(&utxoIDs, "utxo-ids", nil, "a list of UTXO IDs ([TX-ID]:[OUTPUT-INDEX]) to consume exclusively")
+	rootCmd.PersistentFlags().StringSliceVar(&excludedUTXOIDs, "exclude-utxo-ids", nil, "a list of UTXO IDs ([TX-ID]:[OUTPUT-INDEX]) to never consume")
 }
 
 func Execute() error {
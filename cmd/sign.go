@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+// SignCommand implements "subnet-cli sign" command.
+func SignCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Signs a partially signed transaction",
+		Long: `
+Prints a partially signed transaction (exported via "--export-tx-path")
+for review, then adds the signatures of the key to it. Does not require
+network access.
+
+$ subnet-cli sign \
+--private-key-path=.insecure.ewoq.key \
+--tx-path=add-subnet-validator.tx
+
+`,
+		RunE: signFunc,
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
+	cmd.PersistentFlags().StringVar(&txPath, "tx-path", "", "partially signed transaction file path")
+
+	return cmd
+}
+
+var errNoTxPath = errors.New("no transaction file path provided")
+
+func signFunc(cmd *cobra.Command, args []string) error {
+	if txPath == "" {
+		return errNoTxPath
+	}
+	ptx, err := client.LoadPartialTx(txPath)
+	if err != nil {
+		return err
+	}
+	k, err := LoadKey(ptx.NetworkID, nil)
+	if err != nil {
+		return err
+	}
+
+	// review what the transaction does before signing it
+	s, err := client.SummarizeTx(ptx.Tx)
+	if err != nil {
+		return err
+	}
+	buf, tb := TxTableSetup()
+	if err := appendTxDetails(tb, ptx.NetworkID, ptx.Tx.Unsigned); err != nil {
+		return err
+	}
+	if err := AppendTxSummary(tb, s); err != nil {
+		return err
+	}
+	tb.Render()
+	msg := buf.String()
+	if enablePrompt {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to sign the transaction, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{red}}No, stop it!{{/}}"),
+				formatter.F("{{green}}Yes, let's sign! {{bold}}{{underline}}I reviewed the transaction{{/}}{{green}}!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 0 {
+			return nil
+		}
+	}
+
+	signed, err := ptx.Sign(k)
+	if err != nil {
+		return err
+	}
+	if len(signed) == 0 {
+		color.Outf("{{yellow}}no missing signatures can be provided by %s{{/}}\n", k.P()[0])
+	} else {
+		if err := ptx.Save(txPath); err != nil {
+			return err
+		}
+		color.Outf("{{magenta}}added %d signature(s) to %q{{/}}\n", len(signed), txPath)
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreatePartialTxTable(ptx))
+	return nil
+}
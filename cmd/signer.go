@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+const signerTokenEnv = "SUBNET_CLI_SIGNER_TOKEN"
+
+var (
+	listenAddress   string
+	signerNetworkID uint32
+)
+
+// SignerCommand implements "subnet-cli signer" command.
+func SignerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signer",
+		Short: "Sub-commands for running a remote signer",
+	}
+	cmd.AddCommand(
+		newSignerServeCommand(),
+	)
+	return cmd
+}
+
+func newSignerServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serves a key to sign with \"--remote-signer-uri\"",
+		Long: `
+Serves the remote signer protocol with the keys of "--private-key-path",
+as a reference for signers backed by an HSM or a cloud KMS. Requests
+must carry the token of $SUBNET_CLI_SIGNER_TOKEN, if set.
+
+$ SUBNET_CLI_SIGNER_TOKEN=secret subnet-cli signer serve \
+--private-key-path=.insecure.ewoq.key \
+--listen-address=127.0.0.1:9660
+
+$ SUBNET_CLI_SIGNER_TOKEN=secret subnet-cli create subnet \
+--remote-signer-uri=http://127.0.0.1:9660 \
+--public-uri=http://localhost:52250
+
+`,
+		RunE: signerServeFunc,
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to serve several keys)")
+	cmd.PersistentFlags().StringVar(&listenAddress, "listen-address", "127.0.0.1:9660", "address to listen on")
+	cmd.PersistentFlags().Uint32Var(&signerNetworkID, "network-id", constants.FujiID, "network ID to format the served addresses with in logs")
+
+	return cmd
+}
+
+func signerServeFunc(cmd *cobra.Command, args []string) error {
+	// addresses are served as IDs, the network only formats the logs
+	k, err := LoadKey(signerNetworkID, nil)
+	if err != nil {
+		return err
+	}
+	token := os.Getenv(signerTokenEnv)
+	if token == "" {
+		color.Outf("{{yellow}}$%s is not set, anyone reaching %s can sign with the key{{/}}\n", signerTokenEnv, listenAddress)
+	}
+
+	color.Outf("{{magenta}}serving %s on %s{{/}}\n", k.P(), listenAddress)
+	srv := &http.Server{
+		Addr:              listenAddress,
+		Handler:           key.NewSignerHandler(k, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
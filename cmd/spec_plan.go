@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/olekukonko/tablewriter"
+	"github.com/onsi/ginkgo/v2/formatter"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/spec"
+)
+
+var (
+	errNotPrimaryValidator = errors.New("not a primary network validator")
+	errUnmanagedDrift      = errors.New("unmanaged drift")
+)
+
+// subnetPlan is the set of transactions that converge the on-chain
+// state of a subnet to its spec.
+type subnetPlan struct {
+	spec *spec.Subnet
+	// subnet is the on-chain state, or nil if the subnet must be created.
+	subnet *client.Subnet
+
+	// addPrimaryValidators are the nodes that must stake
+	// [stakeAmount] to validate the primary network first.
+	addPrimaryValidators []ids.NodeID
+	stakeAmount          uint64
+
+	addValidators     []*spec.Validator
+	removeValidators  []ids.NodeID
+	createBlockchains []*spec.Blockchain
+
+	// primaryValidators are the primary network records (or the planned
+	// ones) of [addValidators], which bound their validation period.
+	primaryValidators map[ids.NodeID]*client.Validator
+	// drifts are the differences that can't be converged by a
+	// transaction (e.g., the control keys of an existing subnet).
+	drifts []string
+}
+
+// planSubnet compares [s] to the state of the P-Chain. If [stakeAmt]
+// is not zero, the validators that are not on the primary network yet
+// are planned to stake it until [primaryEnd], instead of failing.
+//
+// If [partial] is set, [s] only lists the validators and blockchains to
+// add (e.g., from flags), so the ones it doesn't list are left as is
+// instead of being removed or reported as drift.
+func planSubnet(cli client.Client, s *spec.Subnet, stakeAmt uint64, primaryEnd time.Time, partial bool) (*subnetPlan, error) {
+	plan := &subnetPlan{
+		spec:              s,
+		stakeAmount:       stakeAmt,
+		primaryValidators: map[ids.NodeID]*client.Validator{},
+	}
+	if s.ID != ids.Empty {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnet, err := cli.P().GetSubnet(ctx, s.ID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("%w (%s)", err, s.ID)
+		}
+		plan.subnet = subnet
+	}
+
+	owners, err := s.Owners()
+	if err != nil {
+		return nil, err
+	}
+	existing := map[ids.NodeID]*client.Validator{}
+	if plan.subnet != nil {
+		if !owners.Equals(plan.subnet.Owners) {
+			plan.drifts = append(plan.drifts, fmt.Sprintf("control keys are %s on chain and can't be changed",
+				FormatOwners(cli.NetworkID(), plan.subnet.Owners)))
+		}
+		for _, vdr := range plan.subnet.Validators {
+			existing[vdr.NodeID] = vdr
+		}
+		for _, vdr := range plan.subnet.PendingValidators {
+			existing[vdr.NodeID] = vdr
+		}
+	}
+
+	wanted := map[ids.NodeID]struct{}{}
+	for _, vdr := range s.Validators {
+		wanted[vdr.NodeID] = struct{}{}
+		cur, ok := existing[vdr.NodeID]
+		if !ok {
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			primary, err := cli.P().GetValidator(ctx, ids.Empty, vdr.NodeID)
+			cancel()
+			switch {
+			case errors.Is(err, client.ErrValidatorNotFound) && stakeAmt > 0:
+				primary = &client.Validator{
+					NodeID: vdr.NodeID,
+					State:  client.ValidatorStatePending,
+					Start:  time.Now().Add(30 * time.Second),
+					End:    primaryEnd,
+					Weight: stakeAmt,
+				}
+				plan.addPrimaryValidators = append(plan.addPrimaryValidators, vdr.NodeID)
+			case errors.Is(err, client.ErrValidatorNotFound):
+				return nil, fmt.Errorf("%w (%s)", errNotPrimaryValidator, vdr.NodeID)
+			case err != nil:
+				return nil, err
+			}
+			if !vdr.End.IsZero() && vdr.End.After(primary.End) {
+				return nil, fmt.Errorf("%w: %s ends at %s after its primary network end %s",
+					spec.ErrInvalidSpec, vdr.NodeID, vdr.End.Format(time.RFC3339), primary.End.Format(time.RFC3339))
+			}
+			plan.primaryValidators[vdr.NodeID] = primary
+			plan.addValidators = append(plan.addValidators, vdr)
+			continue
+		}
+		// a validator can't be updated without removing it first
+		if cur.Weight != vdr.Weight {
+			plan.drifts = append(plan.drifts, fmt.Sprintf("%s has weight %d on chain", vdr.NodeID, cur.Weight))
+		}
+		if !vdr.End.IsZero() && !vdr.End.Equal(cur.End) {
+			plan.drifts = append(plan.drifts, fmt.Sprintf("%s ends at %s on chain", vdr.NodeID, cur.End.Format(time.RFC3339)))
+		}
+	}
+	if plan.subnet != nil && !partial {
+		for _, vdrs := range [][]*client.Validator{plan.subnet.Validators, plan.subnet.PendingValidators} {
+			for _, vdr := range vdrs {
+				if _, ok := wanted[vdr.NodeID]; !ok {
+					plan.removeValidators = append(plan.removeValidators, vdr.NodeID)
+				}
+			}
+		}
+	}
+
+	chains := map[string]*client.Blockchain{}
+	if plan.subnet != nil {
+		for _, bc := range plan.subnet.Blockchains {
+			chains[bc.Name] = bc
+		}
+	}
+	names := map[string]struct{}{}
+	for _, bc := range s.Blockchains {
+		names[bc.Name] = struct{}{}
+		cur, ok := chains[bc.Name]
+		if !ok {
+			plan.createBlockchains = append(plan.createBlockchains, bc)
+			continue
+		}
+		if cur.VMID != bc.VMID {
+			plan.drifts = append(plan.drifts, fmt.Sprintf("blockchain %q (%s) runs VM %s on chain", bc.Name, cur.ID, cur.VMID))
+		}
+	}
+	if plan.subnet != nil && !partial {
+		for _, bc := range plan.subnet.Blockchains {
+			if _, ok := names[bc.Name]; !ok {
+				plan.drifts = append(plan.drifts, fmt.Sprintf("blockchain %q (%s) is not in the spec and can't be removed", bc.Name, bc.ID))
+			}
+		}
+	}
+	return plan, nil
+}
+
+// empty returns true if no transaction is needed.
+func (p *subnetPlan) empty() bool {
+	return p.subnet != nil &&
+		len(p.addPrimaryValidators) == 0 &&
+		len(p.addValidators) == 0 &&
+		len(p.removeValidators) == 0 &&
+		len(p.createBlockchains) == 0
+}
+
+// txFee returns the fee burned by all the transactions of the plan.
+func (p *subnetPlan) txFee(feeData *info.GetTxFeeResponse) uint64 {
+	fee := uint64(feeData.TxFee) * uint64(len(p.addValidators)+len(p.removeValidators))
+	fee += uint64(feeData.CreateBlockchainTxFee) * uint64(len(p.createBlockchains))
+	fee += uint64(feeData.AddPrimaryNetworkValidatorFee) * uint64(len(p.addPrimaryValidators))
+	if p.subnet == nil {
+		fee += uint64(feeData.CreateSubnetTxFee)
+	}
+	return fee
+}
+
+// totalStake returns the amount staked by all the primary network
+// validators of the plan.
+func (p *subnetPlan) totalStake() uint64 {
+	return p.stakeAmount * uint64(len(p.addPrimaryValidators))
+}
+
+// validatePeriod returns the validation period of [vdr], bounded by its
+// period on the primary network.
+func (p *subnetPlan) validatePeriod(vdr *spec.Validator) (time.Time, time.Time) {
+	primary := p.primaryValidators[vdr.NodeID]
+	start := time.Now().Add(30 * time.Second)
+	if primary.Start.After(start) {
+		// the node is still pending on the primary network
+		start = primary.Start
+	}
+	end := vdr.End
+	if end.IsZero() {
+		end = primary.End
+	}
+	return start, end
+}
+
+// appendTable appends the changes of the plan to [tb].
+func (p *subnetPlan) appendTable(tb *tablewriter.Table, networkID uint32) {
+	if p.subnet == nil {
+		owners, _ := p.spec.Owners()
+		tb.Append([]string{formatter.F("{{magenta}}CREATE SUBNET{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", FormatOwners(networkID, owners))})
+	} else {
+		tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", p.subnet.ID)})
+	}
+	if len(p.addPrimaryValidators) > 0 {
+		tb.Append([]string{formatter.F("{{green}}ADD PRIMARY VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", p.addPrimaryValidators)})
+	}
+	if len(p.addValidators) > 0 {
+		vdrs := make([]string, len(p.addValidators))
+		for idx, vdr := range p.addValidators {
+			_, end := p.validatePeriod(vdr)
+			vdrs[idx] = fmt.Sprintf("%s (weight %d, until %s)", vdr.NodeID, vdr.Weight, end.Format(time.RFC3339))
+		}
+		tb.Append([]string{formatter.F("{{green}}ADD VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(vdrs, "\n"))})
+	}
+	if len(p.removeValidators) > 0 {
+		tb.Append([]string{formatter.F("{{red}}REMOVE VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", p.removeValidators)})
+	}
+	if len(p.createBlockchains) > 0 {
+		bcs := make([]string, len(p.createBlockchains))
+		for idx, bc := range p.createBlockchains {
+			bcs[idx] = fmt.Sprintf("%s (VM ID %s, genesis %s)", bc.Name, bc.VMID, bc.GenesisPath)
+		}
+		tb.Append([]string{formatter.F("{{green}}CREATE BLOCKCHAINS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(bcs, "\n"))})
+	}
+	if len(p.drifts) > 0 {
+		tb.Append([]string{formatter.F("{{yellow}}UNMANAGED DRIFT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(p.drifts, "\n"))})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/spec"
+)
+
+// fakeClient serves a subnet and the primary network validators,
+// and panics on any other request.
+type fakeClient struct {
+	client.Client
+	p *fakeP
+}
+
+func (c *fakeClient) NetworkID() uint32 { return constants.LocalID }
+func (c *fakeClient) P() client.P       { return c.p }
+
+type fakeP struct {
+	client.P
+	subnet  *client.Subnet
+	primary map[ids.NodeID]*client.Validator
+}
+
+func (p *fakeP) GetSubnet(_ context.Context, subnetID ids.ID) (*client.Subnet, error) {
+	if p.subnet == nil || p.subnet.ID != subnetID {
+		return nil, client.ErrSubnetNotFound
+	}
+	return p.subnet, nil
+}
+
+func (p *fakeP) GetValidator(_ context.Context, subnetID ids.ID, nodeID ids.NodeID) (*client.Validator, error) {
+	vdr, ok := p.primary[nodeID]
+	if subnetID != ids.Empty || !ok {
+		return nil, client.ErrValidatorNotFound
+	}
+	return vdr, nil
+}
+
+func TestPlanSubnet(t *testing.T) {
+	t.Parallel()
+
+	owner := ids.GenerateTestShortID()
+	end := time.Now().Add(time.Hour).Truncate(time.Second)
+	oldNode, newNode := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	vmID := ids.GenerateTestID()
+	subnet := &client.Subnet{
+		ID:     ids.GenerateTestID(),
+		Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+		Validators: []*client.Validator{
+			{NodeID: oldNode, Weight: 1000, End: end},
+		},
+		Blockchains: []*client.Blockchain{
+			{ID: ids.GenerateTestID(), Name: "old", VMID: vmID},
+		},
+	}
+	cli := &fakeClient{p: &fakeP{
+		subnet: subnet,
+		primary: map[ids.NodeID]*client.Validator{
+			oldNode: {NodeID: oldNode, End: end},
+			newNode: {NodeID: newNode, End: end},
+		},
+	}}
+	// only lists the new validator and blockchain
+	s := &spec.Subnet{
+		ID:          subnet.ID,
+		ControlKeys: FormatAddrs(constants.LocalID, []ids.ShortID{owner}),
+		Threshold:   1,
+		Validators:  []*spec.Validator{{NodeID: newNode, Weight: 1000}},
+		Blockchains: []*spec.Blockchain{{Name: "new", VMID: vmID}},
+	}
+
+	tt := []struct {
+		name       string
+		partial    bool
+		expRemoves int
+		expDrifts  int
+	}{
+		{
+			// the spec is the whole subnet
+			name:       "spec",
+			expRemoves: 1,
+			expDrifts:  1,
+		},
+		{
+			// the flags only add
+			name:    "flags",
+			partial: true,
+		},
+	}
+	for i, tv := range tt {
+		plan, err := planSubnet(cli, s, 0, time.Time{}, tv.partial)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if len(plan.addValidators) != 1 || plan.addValidators[0].NodeID != newNode {
+			t.Fatalf("#%d(%s): unexpected validators to add %v", i, tv.name, plan.addValidators)
+		}
+		if len(plan.createBlockchains) != 1 || plan.createBlockchains[0].Name != "new" {
+			t.Fatalf("#%d(%s): unexpected blockchains to create %v", i, tv.name, plan.createBlockchains)
+		}
+		if len(plan.removeValidators) != tv.expRemoves {
+			t.Fatalf("#%d(%s): unexpected validators to remove %v, expected %d", i, tv.name, plan.removeValidators, tv.expRemoves)
+		}
+		if len(plan.drifts) != tv.expDrifts {
+			t.Fatalf("#%d(%s): unexpected drifts %v, expected %d", i, tv.name, plan.drifts, tv.expDrifts)
+		}
+	}
+
+	// a listed validator is still compared to the chain
+	s.Validators = []*spec.Validator{{NodeID: oldNode, Weight: 2000}}
+	s.Blockchains = nil
+	plan, err := planSubnet(cli, s, 0, time.Time{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.empty() || len(plan.drifts) != 1 {
+		t.Fatalf("unexpected plan with drifts %v, expected the weight drift only", plan.drifts)
+	}
+}
@@ -15,6 +15,7 @@ func StatusCommand() *cobra.Command {
 	}
 	cmd.AddCommand(
 		newStatusBlockchainCommand(),
+		newStatusSubnetCommand(),
 	)
 	cmd.PersistentFlags().StringVar(&privateURI, "private-uri", "", "URI for avalanche network endpoints")
 	return cmd
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+func newStatusSubnetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet",
+		Short: "Reports the owners, validators and blockchains of a subnet",
+		Long: `
+Reports the control keys and threshold, the current and pending
+validators, and the blockchains of the subnet.
+
+$ subnet-cli status subnet \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--private-uri=http://localhost:49738
+
+`,
+		RunE: statusSubnetFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	return cmd
+}
+
+func statusSubnetFunc(cmd *cobra.Command, args []string) error {
+	cli, info, err := InitClient(privateURI, false)
+	if err != nil {
+		return err
+	}
+
+	subnetID, err := ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	color.Outf("\n{{blue}}Checking subnet...{{/}}\n")
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	s, err := cli.P().GetSubnet(ctx, subnetID)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	buf, tb := TxTableSetup()
+	tb.Append([]string{formatter.F("{{orange}}URI{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", info.uri)})
+	tb.Append([]string{formatter.F("{{orange}}NETWORK NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", info.networkName)})
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", s.ID)})
+	tb.Append([]string{formatter.F("{{blue}}CONTROL KEYS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(FormatAddrs(info.networkID, s.Owners.Addrs), "\n"))})
+	tb.Append([]string{formatter.F("{{blue}}THRESHOLD{{/}}"), formatter.F("{{light-gray}}{{bold}}%d{{/}}", s.Owners.Threshold)})
+	tb.Append([]string{formatter.F("{{cyan}}VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", formatValidators(s.Validators))})
+	tb.Append([]string{formatter.F("{{cyan}}PENDING VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", formatValidators(s.PendingValidators))})
+	bcs := make([]string, len(s.Blockchains))
+	for idx, bc := range s.Blockchains {
+		bcs[idx] = fmt.Sprintf("%s (%s, VM ID %s, %s)", bc.ID, bc.Name, bc.VMID, bc.Status)
+	}
+	tb.Append([]string{formatter.F("{{green}}BLOCKCHAINS{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", strings.Join(bcs, "\n"))})
+	tb.Render()
+	fmt.Fprint(formatter.ColorableStdOut, buf.String())
+	return nil
+}
+
+func formatValidators(vdrs []*client.Validator) string {
+	lines := make([]string, len(vdrs))
+	for idx, vdr := range vdrs {
+		lines[idx] = fmt.Sprintf("%s (weight %d, %s to %s)",
+			vdr.NodeID, vdr.Weight, vdr.Start.Format(time.RFC3339), vdr.End.Format(time.RFC3339))
+	}
+	return strings.Join(lines, "\n")
+}
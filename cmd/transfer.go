@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/internal/key"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+	"github.com/dustin/go-humanize"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+// TransferCommand implements "subnet-cli transfer" command.
+func TransferCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Sub-commands for moving AVAX between chains",
+	}
+	cmd.AddCommand(
+		newTransferCommand("X", "P"),
+		newTransferCommand("C", "P"),
+		newTransferCommand("P", "X"),
+		newTransferCommand("P", "C"),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
+	cmd.PersistentFlags().Uint64Var(&transferAmount, "amount", 0, "amount denominated in nano AVAX to transfer")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the P-Chain export transaction, and print it without issuing")
+	return cmd
+}
+
+var errDryRunTransfer = errors.New("can only dry run transfers from the P-Chain")
+
+// transferChain is implemented by the chain clients
+// that can export and import AVAX.
+type transferChain interface {
+	Balance(ctx context.Context, k key.Key) (uint64, error)
+	Export(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		amount uint64,
+		opts ...client.OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+	Import(
+		ctx context.Context,
+		k key.Key,
+		chainID ids.ID,
+		opts ...client.OpOption,
+	) (txID ids.ID, took time.Duration, err error)
+}
+
+func newTransferCommand(src string, dst string) *cobra.Command {
+	name := strings.ToLower(src + "-to-" + dst)
+	return &cobra.Command{
+		Use:   name,
+		Short: fmt.Sprintf("Transfers AVAX from the %s-Chain to the %s-Chain", src, dst),
+		Long: fmt.Sprintf(`
+Exports AVAX from the %[1]s-Chain and imports it to the %[2]s-Chain,
+both owned by the same key.
+
+$ subnet-cli transfer %[3]s \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--amount=1000000000
+
+`, src, dst, name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transferFunc(src, dst)
+		},
+	}
+}
+
+func transferChainOf(cli client.Client, alias string) (transferChain, ids.ID) {
+	switch alias {
+	case "X":
+		return cli.X(), cli.XChainID()
+	case "C":
+		return cli.C(), cli.CChainID()
+	default:
+		return cli.P(), constants.PlatformChainID
+	}
+}
+
+func transferFunc(src string, dst string) error {
+	if transferAmount == 0 {
+		return ErrInvalidTransferAmount
+	}
+	if dryRun && src != "P" {
+		return errDryRunTransfer
+	}
+	exportOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	exportOpts = append(exportOpts, dryOpts...)
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	srcChain, srcChainID := transferChainOf(cli, src)
+	dstChain, dstChainID := transferChainOf(cli, dst)
+	info.srcChain, info.dstChain = src, dst
+	info.transferAmount = transferAmount
+
+	// C-Chain atomic tx fees are dynamic and only known when
+	// the tx is built, so only the X/P-Chain fees are shown
+	for _, alias := range []string{src, dst} {
+		if alias != "C" {
+			info.txFee += uint64(info.feeData.TxFee)
+		}
+	}
+	if src == "P" {
+		info.requiredBalance = transferAmount + uint64(info.feeData.TxFee)
+		if err := info.CheckBalance(); err != nil {
+			return err
+		}
+		if err := info.SelectInputs(cli, info.requiredBalance, 0, exportOpts); err != nil {
+			return err
+		}
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		info.srcBalance, err = srcChain.Balance(ctx, info.key)
+		cancel()
+		if err != nil {
+			return err
+		}
+		required := transferAmount
+		if src == "X" {
+			required += uint64(info.feeData.TxFee)
+		}
+		if info.srcBalance < required {
+			color.Outf("{{red}}insufficient funds on the %s-Chain to perform transfer{{/}}\n", src)
+			if src == "C" {
+				return fmt.Errorf("%w: on %s-Chain (expected>=%d plus the dynamic fee, have=%d)", ErrInsufficientFunds, src, required, info.srcBalance)
+			}
+			return fmt.Errorf("%w: on %s-Chain (expected>=%d, have=%d)", ErrInsufficientFunds, src, required, info.srcBalance)
+		}
+	}
+
+	msg := CreateTransferTable(info)
+	if enablePrompt && !dryRun {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to transfer AVAX, should we continue?{{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt && !dryRun {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's transfer! {{bold}}{{underline}}I agree to pay the fee{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+
+	println()
+	println()
+	println()
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	txID, took, err := srcChain.Export(ctx, info.key, dstChainID, transferAmount, exportOpts...)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		// the import can only be built once the export is accepted
+		return PrintDryRunTx(pTx)
+	}
+	color.Outf("{{magenta}}exported %s $AVAX from the %s-Chain{{/}} %q {{light-gray}}(took %v){{/}}\n", formatAVAX(transferAmount), src, txID, took)
+
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	txID, took, err = dstChain.Import(ctx, info.key, srcChainID)
+	cancel()
+	if err != nil {
+		color.Outf("{{red}}exported funds are not lost, re-run the transfer to import them{{/}}\n")
+		return err
+	}
+	color.Outf("{{magenta}}imported to the %s-Chain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", dst, txID, took)
+
+	info.requiredBalance = 0
+	info.txFee = 0
+	info.transferAmount = 0
+	info.inputs = nil
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if src != "P" {
+		ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+		info.srcBalance, err = srcChain.Balance(ctx, info.key)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreateTransferTable(info))
+	return nil
+}
+
+func CreateTransferTable(i *Info) string {
+	buf, tb := BaseTableSetup(i)
+	if i.srcChain != "P" {
+		tb.Append([]string{formatter.F("{{coral}}{{bold}}TOTAL %s-CHAIN BALANCE{{/}}", i.srcChain), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", formatAVAX(i.srcBalance))})
+	}
+	tb.Append([]string{formatter.F("{{blue}}SOURCE CHAIN{{/}}"), formatter.F("{{light-gray}}{{bold}}%s-Chain{{/}}", i.srcChain)})
+	tb.Append([]string{formatter.F("{{blue}}DESTINATION CHAIN{{/}}"), formatter.F("{{light-gray}}{{bold}}%s-Chain{{/}}", i.dstChain)})
+	if i.transferAmount > 0 {
+		tb.Append([]string{formatter.F("{{red}}{{bold}}TRANSFER AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} $AVAX", formatAVAX(i.transferAmount))})
+	}
+	tb.Render()
+	return buf.String()
+}
+
+func formatAVAX(v uint64) string {
+	return humanize.FormatFloat("#,###.#######", float64(v)/float64(units.Avax))
+}
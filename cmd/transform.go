@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"github.com/dustin/go-humanize"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+// TransformCommand implements "subnet-cli transform" command.
+func TransformCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transform",
+		Short: "Sub-commands for transforming resources",
+	}
+	cmd.AddCommand(
+		newTransformSubnetCommand(),
+	)
+	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
+	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
+	return cmd
+}
+
+func CreateTransformTable(i *Info) string {
+	buf, tb := BaseTableSetup(i)
+	tb.Append([]string{formatter.F("{{blue}}SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.subnetID)})
+	cfg := i.transformConfig
+	if cfg == nil {
+		tb.Render()
+		return buf.String()
+	}
+	row := func(k string, format string, args ...interface{}) {
+		tb.Append([]string{formatter.F("{{magenta}}%s{{/}}", k), formatter.F("{{light-gray}}{{bold}}"+format+"{{/}}", args...)})
+	}
+	row("ASSET ID", "%s", cfg.AssetID)
+	row("INITIAL SUPPLY", "%s", humanize.Comma(int64(cfg.InitialSupply)))
+	row("MAXIMUM SUPPLY", "%s", humanize.Comma(int64(cfg.MaximumSupply)))
+	tb.Append([]string{formatter.F("{{red}}{{bold}}BURNED REWARD AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} of %s", humanize.Comma(int64(cfg.RewardAmount())), cfg.AssetID)})
+	row("CONSUMPTION RATE", "%s %% minimum, %s %% maximum", formatPercent(cfg.MinConsumptionRate), formatPercent(cfg.MaxConsumptionRate))
+	row("VALIDATOR STAKE", "%s minimum, %s maximum", humanize.Comma(int64(cfg.MinValidatorStake)), humanize.Comma(int64(cfg.MaxValidatorStake)))
+	row("STAKE DURATION", "%v minimum, %v maximum", cfg.MinStakeDuration, cfg.MaxStakeDuration)
+	row("MIN DELEGATION FEE", "%s %%", formatPercent(uint64(cfg.MinDelegationFee)))
+	row("MIN DELEGATOR STAKE", "%s", humanize.Comma(int64(cfg.MinDelegatorStake)))
+	row("MAX VALIDATOR WEIGHT FACTOR", "%d", cfg.MaxValidatorWeightFactor)
+	row("UPTIME REQUIREMENT", "%s %%", formatPercent(uint64(cfg.UptimeRequirement)))
+	tb.Render()
+	return buf.String()
+}
+
+// formatPercent formats [v], denominated in "reward.PercentDenominator",
+// as a percentage.
+func formatPercent(v uint64) string {
+	return humanize.FormatFloat("#,###.####", float64(v)/float64(percentUnit))
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/manifoldco/promptui"
+	"github.com/onsi/ginkgo/v2/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+// percentUnit is the value of 1 % in "reward.PercentDenominator".
+const percentUnit = reward.PercentDenominator / 100
+
+func newTransformSubnetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subnet",
+		Short: "Transforms a subnet into a permissionless subnet",
+		Long: `
+Transforms a subnet into a permissionless (elastic) subnet, staked with
+an asset on the P-Chain. The rewards (maximum supply minus the initial
+supply) are burned from the key, to be minted to the stakers.
+
+$ subnet-cli transform subnet \
+--private-key-path=.insecure.ewoq.key \
+--public-uri=http://localhost:52250 \
+--subnet-id="24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" \
+--asset-id="2fombhL7aGPwj3KH4bfrmJwW6PVnMobf9Y2fn9GwxiAAJyFDbe" \
+--initial-supply=240000000000000000 \
+--maximum-supply=720000000000000000 \
+--min-validator-stake=2000000000000 \
+--max-validator-stake=3000000000000000 \
+--min-delegator-stake=25000000000
+
+`,
+		RunE: transformSubnetFunc,
+	}
+
+	cmd.PersistentFlags().StringVar(&subnetIDs, "subnet-id", "", "subnet ID (must be formatted in ids.ID)")
+	cmd.PersistentFlags().StringVar(&assetIDs, "asset-id", "", "ID of the P-Chain asset to stake (must be formatted in ids.ID, must not be AVAX)")
+	cmd.PersistentFlags().Uint64Var(&initialSupply, "initial-supply", 0, "current supply of the asset")
+	cmd.PersistentFlags().Uint64Var(&maximumSupply, "maximum-supply", 0, "maximum supply of the asset, the difference with the initial supply is burned to be minted as rewards")
+	cmd.PersistentFlags().Uint64Var(&minConsumptionRatePercent, "min-consumption-rate-percent", 10, "percentage of the remaining supply rewarded for a stake of zero duration")
+	cmd.PersistentFlags().Uint64Var(&maxConsumptionRatePercent, "max-consumption-rate-percent", 12, "percentage of the remaining supply rewarded for a stake over the minting period")
+	cmd.PersistentFlags().Uint64Var(&minValidatorStake, "min-validator-stake", 0, "minimum amount of the asset a validator must stake")
+	cmd.PersistentFlags().Uint64Var(&maxValidatorStake, "max-validator-stake", 0, "maximum amount of the asset a validator can be allocated, including delegations")
+	cmd.PersistentFlags().DurationVar(&minStakeDuration, "min-stake-duration", 24*time.Hour, "minimum duration of a stake")
+	cmd.PersistentFlags().DurationVar(&maxStakeDuration, "max-stake-duration", 365*24*time.Hour, "maximum duration of a stake")
+	cmd.PersistentFlags().Uint32Var(&minDelegationFeePercent, "min-delegation-fee-percent", defaultValFeePercent, "minimum percentage a validator must charge its delegators")
+	cmd.PersistentFlags().Uint64Var(&minDelegatorStake, "min-delegator-stake", 0, "minimum amount of the asset a delegator must stake")
+	cmd.PersistentFlags().Uint8Var(&maxValidatorWeightFactor, "max-validator-weight-factor", 5, "factor of its own stake a validator can receive in delegations (1 disables delegation)")
+	cmd.PersistentFlags().Uint32Var(&uptimeRequirementPercent, "uptime-requirement-percent", 80, "percentage of uptime a validator must have to be rewarded")
+	cmd.PersistentFlags().StringVar(&exportTxPath, "export-tx-path", "", "file path to export the partially signed transaction to, for other subnet control keys to sign (does not issue)")
+	cmd.PersistentFlags().StringSliceVar(&watchAddresses, "address", nil, "a list of P-Chain addresses to build transactions for without their keys (watch-only, requires --export-tx-path)")
+	cmd.PersistentFlags().StringSliceVar(&subnetAuthKeys, "subnet-auth-keys", nil, "a list of control key P-Chain addresses to sign the exported transaction (default to the key owner's, then others in order)")
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "build, sign and verify the transaction, and print it without issuing")
+
+	return cmd
+}
+
+func transformSubnetFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
+	cli, info, err := InitClient(publicURI, true)
+	if err != nil {
+		return err
+	}
+	info.subnetID, err = ids.FromString(subnetIDs)
+	if err != nil {
+		return err
+	}
+	assetID, err := ids.FromString(assetIDs)
+	if err != nil {
+		return err
+	}
+	info.transformConfig = &client.TransformSubnetConfig{
+		AssetID:                  assetID,
+		InitialSupply:            initialSupply,
+		MaximumSupply:            maximumSupply,
+		MinConsumptionRate:       minConsumptionRatePercent * percentUnit,
+		MaxConsumptionRate:       maxConsumptionRatePercent * percentUnit,
+		MinValidatorStake:        minValidatorStake,
+		MaxValidatorStake:        maxValidatorStake,
+		MinStakeDuration:         minStakeDuration,
+		MaxStakeDuration:         maxStakeDuration,
+		MinDelegationFee:         minDelegationFeePercent * percentUnit,
+		MinDelegatorStake:        minDelegatorStake,
+		MaxValidatorWeightFactor: maxValidatorWeightFactor,
+		UptimeRequirement:        uptimeRequirementPercent * percentUnit,
+	}
+	info.txFee = uint64(info.feeData.TransformSubnetTxFee)
+	info.requiredBalance = info.txFee
+	if err := info.CheckBalance(); err != nil {
+		return err
+	}
+	if err := info.SelectInputs(cli, info.txFee, 0, selectOpts); err != nil {
+		return err
+	}
+	ptx := new(client.PartialTx)
+	opts, err := PartialTxOpts(ptx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, selectOpts...)
+	pTx := new(txs.Tx)
+	dryOpts, err := DryRunOpts(pTx)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, dryOpts...)
+
+	msg := CreateTransformTable(info)
+	if enablePrompt && !dryRun {
+		msg = formatter.F("\n{{blue}}{{bold}}Ready to transform subnet, should we continue? (this can't be undone){{/}}\n") + msg
+	}
+	fmt.Fprint(formatter.ColorableStdOut, msg)
+
+	if enablePrompt && exportTxPath == "" && !dryRun {
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, let's transform! {{bold}}{{underline}}I agree to pay the fee and burn the rewards{{/}}{{green}}!{{/}}"),
+				formatter.F("{{red}}No, stop it!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+		if idx == 1 {
+			return nil
+		}
+	}
+	println()
+	println()
+	println()
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	took, err := cli.P().TransformSubnet(
+		ctx,
+		info.key,
+		info.subnetID,
+		info.transformConfig,
+		opts...,
+	)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if exportTxPath != "" {
+		return ExportPartialTx(ptx, exportTxPath)
+	}
+	if dryRun {
+		return PrintDryRunTx(pTx)
+	}
+	color.Outf("{{magenta}}transformed subnet{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.subnetID, took)
+
+	info.requiredBalance = 0
+	info.txFee = 0
+	info.inputs = nil
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	info.balance, err = cli.P().Balance(ctx, info.key)
+	cancel()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(formatter.ColorableStdOut, CreateTransformTable(info))
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/dustin/go-humanize"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
@@ -31,8 +32,9 @@ func WizardCommand() *cobra.Command {
 
 	// "create subnet"
 	cmd.PersistentFlags().StringVar(&publicURI, "public-uri", "https://api.avax-test.network", "URI for avalanche network endpoints")
-	cmd.PersistentFlags().StringVar(&privKeyPath, "private-key-path", ".subnet-cli.pk", "private key file path")
+	cmd.PersistentFlags().StringSliceVar(&privKeyPaths, "private-key-path", []string{defaultPrivKeyPath}, "private key file path (repeat to sign with several keys)")
 	cmd.PersistentFlags().BoolVarP(&useLedger, "ledger", "l", false, "use ledger to sign transactions")
+	cmd.PersistentFlags().StringVar(&remoteSignerURI, "remote-signer-uri", "", "URI of a remote signer to sign transactions with (token read from $SUBNET_CLI_SIGNER_TOKEN)")
 
 	// "add validator"
 	cmd.PersistentFlags().StringSliceVar(&nodeIDs, "node-ids", nil, "a list of node IDs (must be formatted in ids.ID)")
@@ -44,10 +46,16 @@ func WizardCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&vmIDs, "vm-id", "", "VM ID (must be formatted in ids.ID)")
 	cmd.PersistentFlags().StringVar(&vmGenesisPath, "vm-genesis-path", "", "VM genesis file path")
 
+	cmd.PersistentFlags().StringVar(&journalPath, "journal-path", ".subnet-cli.wizard.json", "file path to record completed steps to, and resume from")
+
 	return cmd
 }
 
 func wizardFunc(cmd *cobra.Command, args []string) error {
+	selectOpts, err := CoinSelectionOpts()
+	if err != nil {
+		return err
+	}
 	cli, info, err := InitClient(publicURI, true)
 	if err != nil {
 		return err
@@ -69,8 +77,8 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	}
 	info.validateWeight = defaultValidateWeight
 	info.validateRewardFeePercent = defaultValFeePercent
-	info.rewardAddr = info.key.Addresses()[0]
-	info.changeAddr = info.key.Addresses()[0]
+	info.rewardOwner = &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{info.key.Addresses()[0]}}
+	info.changeOwner = info.rewardOwner
 	info.vmID, err = ids.FromString(vmIDs)
 	if err != nil {
 		return err
@@ -82,15 +90,68 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	info.chainName = chainName
 	info.vmGenesisPath = vmGenesisPath
 
+	// Resume from the steps completed by a previous run
+	journal, err := loadWizardJournal(journalPath)
+	if err != nil {
+		return err
+	}
+	if journal != nil && enablePrompt {
+		color.Outf("\n{{blue}}{{bold}}Found wizard journal %q, should we resume?{{/}}\n", journalPath)
+		prompt := promptui.Select{
+			Label:  "\n",
+			Stdout: os.Stdout,
+			Items: []string{
+				formatter.F("{{green}}Yes, resume and skip the completed steps!{{/}}"),
+				formatter.F("{{red}}No, start over!{{/}}"),
+			},
+		}
+		idx, _, err := prompt.Run()
+		if err != nil {
+			return err
+		}
+		if idx == 1 {
+			journal = nil
+		}
+	}
+	if journal != nil {
+		// without a prompt, always resume so that no step is paid twice
+		color.Outf("{{blue}}resuming from wizard journal %q{{/}}\n", journalPath)
+		if err := journal.verify(cli, info); err != nil {
+			return err
+		}
+	}
+	if journal == nil {
+		journal = newWizardJournal(journalPath, info)
+	}
+	if journal.BlockchainID != ids.Empty {
+		color.Outf("{{magenta}}all steps already completed{{/}}\n")
+		info.subnetID, info.blockchainID = journal.SubnetID, journal.BlockchainID
+		info.nodeIDs = nil
+		fmt.Fprint(formatter.ColorableStdOut, CreateSpellPostTable(info))
+		return nil
+	}
+	subnetNodeIDs := []ids.NodeID{}
+	for _, nodeID := range info.allNodeIDs {
+		if _, ok := journal.SubnetValidators[nodeID]; !ok {
+			subnetNodeIDs = append(subnetNodeIDs, nodeID)
+		}
+	}
+
 	// Compute dry run cost/actions for approval
 	info.totalStakeAmount = uint64(len(info.nodeIDs)) * info.stakeAmount
-	info.txFee = uint64(info.feeData.CreateSubnetTxFee) + uint64(info.feeData.TxFee)*uint64(len(info.allNodeIDs)) + uint64(info.feeData.CreateBlockchainTxFee)
+	info.txFee = uint64(info.feeData.TxFee)*uint64(len(subnetNodeIDs)) + uint64(info.feeData.CreateBlockchainTxFee)
+	if journal.SubnetID == ids.Empty {
+		info.txFee += uint64(info.feeData.CreateSubnetTxFee)
+	}
 	info.requiredBalance = info.stakeAmount + info.txFee
 	if err := info.CheckBalance(); err != nil {
 		return err
 	}
+	if err := info.SelectInputs(cli, info.txFee, info.totalStakeAmount, selectOpts); err != nil {
+		return err
+	}
 
-	msg := CreateSpellPreTable(info)
+	msg := CreateSpellPreTable(info, journal, subnetNodeIDs)
 	if enablePrompt {
 		msg = formatter.F("\n{{blue}}{{bold}}Ready to run wizard, should we continue?{{/}}\n") + msg
 	}
@@ -115,6 +176,13 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	println()
 
 	// Ensure all nodes are validators on the primary network
+	opts := []client.OpOption{
+		client.WithStakeAmount(info.stakeAmount),
+		client.WithRewardShares(info.validateRewardFeePercent * 10000),
+		client.WithRewardOwner(info.rewardOwner),
+		client.WithChangeOwner(info.changeOwner),
+	}
+	opts = append(opts, selectOpts...)
 	for i, nodeID := range info.nodeIDs {
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 		info.validateStart = time.Now().Add(30 * time.Second)
@@ -124,16 +192,17 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			nodeID,
 			info.validateStart,
 			info.validateEnd,
-			client.WithStakeAmount(info.stakeAmount),
-			client.WithRewardShares(info.validateRewardFeePercent*10000),
-			client.WithRewardAddress(info.rewardAddr),
-			client.WithChangeAddress(info.changeAddr),
+			opts...,
 		)
 		cancel()
 		if err != nil {
 			return err
 		}
 		color.Outf("{{magenta}}added %s to primary network validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, took)
+		journal.Validators[nodeID] = validatorTxID(cli, ids.Empty, nodeID)
+		if err := journal.save(); err != nil {
+			return err
+		}
 		if i < len(info.nodeIDs)-1 {
 			info.validateEnd = info.validateEnd.Add(defaultStagger)
 		}
@@ -145,41 +214,32 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create subnet
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
-	subnetID, took, err := cli.P().CreateSubnet(ctx, info.key)
-	cancel()
-	if err != nil {
-		return err
+	if journal.SubnetID == ids.Empty {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		subnetID, took, err := cli.P().CreateSubnet(ctx, info.key, selectOpts...)
+		cancel()
+		if err != nil {
+			return err
+		}
+		color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", subnetID, took)
+		journal.SubnetID = subnetID
+		if err := journal.save(); err != nil {
+			return err
+		}
 	}
-	info.subnetID = subnetID
-	color.Outf("{{magenta}}created subnet{{/}} %q {{light-gray}}(took %v){{/}}\n", info.subnetID, took)
+	info.subnetID = journal.SubnetID
 
 	// Pause for operator to whitelist subnet on all validators (and to remind
 	// that a binary by the name of [vmIDs] must be in the plugins dir)
-	color.Outf("\n\n\n{{cyan}}Now, time for some config changes on your node(s).\nSet --whitelisted-subnets=%s and move the compiled VM %s to <build-dir>/plugins/%s.\nWhen you're finished, restart your node.{{/}}\n", info.subnetID, info.vmID, info.vmID)
-	prompt = promptui.Select{
-		Label:  "\n",
-		Stdout: os.Stdout,
-		Items: []string{
-			formatter.F("{{green}}Yes, let's continue!{{bold}}{{underline}} I've updated --whitelisted-subnets, built my VM, and restarted my node(s)!{{/}}"),
-			formatter.F("{{red}}No, stop it!{{/}}"),
-		},
-	}
-	idx, _, err = prompt.Run()
-	if err != nil {
-		return nil //nolint:nilerr
-	}
-	if idx == 1 {
+	if len(subnetNodeIDs) > 0 && !promptSubnetConfig(info) {
 		return nil
 	}
-	println()
-	println()
 
 	// Add validators to subnet
-	for _, nodeID := range info.allNodeIDs { // do all nodes, not parsed
+	for _, nodeID := range subnetNodeIDs {
 		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 		valInfo := info.valInfos[nodeID]
-		start := time.Now().Add(30 * time.Second)
+		start := info.ValidateStart(nodeID)
 		took, err := cli.P().AddSubnetValidator(
 			ctx,
 			info.key,
@@ -188,12 +248,17 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 			start,
 			valInfo.end,
 			validateWeight,
+			selectOpts...,
 		)
 		cancel()
 		if err != nil {
 			return err
 		}
 		color.Outf("{{magenta}}added %s to subnet %s validator set{{/}} {{light-gray}}(took %v){{/}}\n\n", nodeID, info.subnetID, took)
+		journal.SubnetValidators[nodeID] = validatorTxID(cli, info.subnetID, nodeID)
+		if err := journal.save(); err != nil {
+			return err
+		}
 	}
 
 	// Because [info.subnetID] was set to the new subnetID, [WaitValidator] will
@@ -203,7 +268,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	println()
 
 	// Add blockchain to subnet
-	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
 	blockchainID, took, err := cli.P().CreateBlockchain(
 		ctx,
 		info.key,
@@ -211,6 +276,7 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 		info.chainName,
 		info.vmID,
 		vmGenesisBytes,
+		selectOpts...,
 	)
 	cancel()
 	if err != nil {
@@ -218,12 +284,17 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	}
 	info.blockchainID = blockchainID
 	color.Outf("{{magenta}}created blockchain{{/}} %q {{light-gray}}(took %v){{/}}\n\n", info.blockchainID, took)
+	journal.BlockchainID = blockchainID
+	if err := journal.save(); err != nil {
+		return err
+	}
 
 	// Print out summary of actions (subnetID, chainID, validator periods)
 	info.requiredBalance = 0
 	info.stakeAmount = 0
 	info.totalStakeAmount = 0
 	info.txFee = 0
+	info.inputs = nil
 	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
 	info.balance, err = cli.P().Balance(ctx, info.key)
 	cancel()
@@ -234,7 +305,40 @@ func wizardFunc(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func CreateSpellPreTable(i *Info) string {
+// promptSubnetConfig asks the operator to whitelist the subnet and
+// install the VM on all validators, and returns false if they stop.
+func promptSubnetConfig(info *Info) bool {
+	color.Outf("\n\n\n{{cyan}}Now, time for some config changes on your node(s).\nSet --whitelisted-subnets=%s and move the compiled VM %s to <build-dir>/plugins/%s.\nWhen you're finished, restart your node.{{/}}\n", info.subnetID, info.vmID, info.vmID)
+	prompt := promptui.Select{
+		Label:  "\n",
+		Stdout: os.Stdout,
+		Items: []string{
+			formatter.F("{{green}}Yes, let's continue!{{bold}}{{underline}} I've updated --whitelisted-subnets, built my VM, and restarted my node(s)!{{/}}"),
+			formatter.F("{{red}}No, stop it!{{/}}"),
+		},
+	}
+	idx, _, err := prompt.Run()
+	if err != nil || idx == 1 {
+		return false
+	}
+	println()
+	println()
+	return true
+}
+
+// validatorTxID returns the ID of the transaction that added [nodeID]
+// to [subnetID], or ids.Empty if it can't be found yet.
+func validatorTxID(cli client.Client, subnetID ids.ID, nodeID ids.NodeID) ids.ID {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	vdr, err := cli.P().GetValidator(ctx, subnetID, nodeID)
+	cancel()
+	if err != nil {
+		return ids.Empty
+	}
+	return vdr.TxID
+}
+
+func CreateSpellPreTable(i *Info, j *wizardJournal, subnetNodeIDs []ids.NodeID) string {
 	buf, tb := BaseTableSetup(i)
 	if len(i.nodeIDs) > 0 {
 		tb.Append([]string{formatter.F("{{magenta}}NEW PRIMARY NETWORK VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.nodeIDs)})
@@ -244,16 +348,19 @@ func CreateSpellPreTable(i *Info) string {
 		tb.Append([]string{formatter.F("{{magenta}}STAKE AMOUNT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}} $AVAX", stakeAmounts)})
 		validateRewardFeePercent := humanize.FormatFloat("#,###.###", float64(i.validateRewardFeePercent))
 		tb.Append([]string{formatter.F("{{magenta}}VALIDATE REWARD FEE{{/}}"), formatter.F("{{light-gray}}{{bold}}{{underline}}%s{{/}} %%", validateRewardFeePercent)})
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}REWARD ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.rewardAddr)})
-		tb.Append([]string{formatter.F("{{cyan}}{{bold}}CHANGE ADDRESS{{/}}"), formatter.F("{{light-gray}}%s{{/}}", i.changeAddr)})
+		i.AppendOwners(tb)
 	}
 
-	tb.Append([]string{formatter.F("{{orange}}NEW SUBNET VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", i.allNodeIDs)})
+	if j.SubnetID != ids.Empty {
+		tb.Append([]string{formatter.F("{{blue}}EXISTING SUBNET ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", j.SubnetID)})
+	}
+	tb.Append([]string{formatter.F("{{orange}}NEW SUBNET VALIDATORS{{/}}"), formatter.F("{{light-gray}}{{bold}}%v{{/}}", subnetNodeIDs)})
 	tb.Append([]string{formatter.F("{{magenta}}SUBNET VALIDATION WEIGHT{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", humanize.Comma(int64(i.validateWeight)))})
 
 	tb.Append([]string{formatter.F("{{dark-green}}CHAIN NAME{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.chainName)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM ID{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmID)})
 	tb.Append([]string{formatter.F("{{dark-green}}VM GENESIS PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", i.vmGenesisPath)})
+	tb.Append([]string{formatter.F("{{orange}}JOURNAL PATH{{/}}"), formatter.F("{{light-gray}}{{bold}}%s{{/}}", j.path)})
 	tb.Render()
 	return buf.String()
 }
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	pstatus "github.com/ava-labs/avalanchego/vms/platformvm/status"
+
+	"github.com/ava-labs/subnet-cli/client"
+	"github.com/ava-labs/subnet-cli/pkg/color"
+)
+
+const journalFileMode = 0o600
+
+var errJournalMismatch = errors.New("wizard journal does not match the flags")
+
+// wizardJournal records the steps of the wizard that completed, so that a
+// failed run can be resumed without paying for them again.
+type wizardJournal struct {
+	path string
+
+	NetworkID uint32 `json:"networkID"`
+	ChainName string `json:"chainName"`
+	VMID      ids.ID `json:"vmID"`
+
+	// Validators maps the nodes added to the primary network
+	// to the ID of the transaction that added them.
+	Validators map[ids.NodeID]ids.ID `json:"validators"`
+	SubnetID   ids.ID                `json:"subnetID"`
+	// SubnetValidators maps the nodes added to the subnet
+	// to the ID of the transaction that added them.
+	SubnetValidators map[ids.NodeID]ids.ID `json:"subnetValidators"`
+	BlockchainID     ids.ID                `json:"blockchainID"`
+}
+
+func newWizardJournal(p string, info *Info) *wizardJournal {
+	return &wizardJournal{
+		path:             p,
+		NetworkID:        info.networkID,
+		ChainName:        info.chainName,
+		VMID:             info.vmID,
+		Validators:       map[ids.NodeID]ids.ID{},
+		SubnetValidators: map[ids.NodeID]ids.ID{},
+	}
+}
+
+// loadWizardJournal loads the journal at [p], or returns nil
+// if there is none.
+func loadWizardJournal(p string) (*wizardJournal, error) {
+	b, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	j := &wizardJournal{}
+	if err := json.Unmarshal(b, j); err != nil {
+		return nil, fmt.Errorf("failed to parse wizard journal %q: %w", p, err)
+	}
+	j.path = p
+	if j.Validators == nil {
+		j.Validators = map[ids.NodeID]ids.ID{}
+	}
+	if j.SubnetValidators == nil {
+		j.SubnetValidators = map[ids.NodeID]ids.ID{}
+	}
+	return j, nil
+}
+
+// save writes the journal to disk after each completed step.
+func (j *wizardJournal) save() error {
+	b, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(j.path, b, journalFileMode)
+}
+
+// verify checks the journal was written for the same subnet spec and
+// drops the steps that can't be found on the chain, so they are run again.
+func (j *wizardJournal) verify(cli client.Client, info *Info) error {
+	if j.NetworkID != info.networkID || j.ChainName != info.chainName || j.VMID != info.vmID {
+		return fmt.Errorf("%w (journal for network %d, chain %q and VM %s)", errJournalMismatch, j.NetworkID, j.ChainName, j.VMID)
+	}
+
+	for nodeID, txID := range j.Validators {
+		found, err := journalValidatorExists(cli, ids.Empty, nodeID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			color.Outf("{{yellow}}%s (tx %s) not found on the primary network, adding it again{{/}}\n", nodeID, txID)
+			delete(j.Validators, nodeID)
+		}
+	}
+	if j.SubnetID == ids.Empty {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	ss, err := cli.P().Client().GetSubnets(ctx, []ids.ID{j.SubnetID})
+	cancel()
+	if err != nil {
+		return err
+	}
+	if len(ss) != 1 || ss[0].ID != j.SubnetID {
+		color.Outf("{{yellow}}subnet %s not found, creating it again{{/}}\n", j.SubnetID)
+		j.SubnetID = ids.Empty
+		j.SubnetValidators = map[ids.NodeID]ids.ID{}
+		j.BlockchainID = ids.Empty
+		return nil
+	}
+	for nodeID, txID := range j.SubnetValidators {
+		found, err := journalValidatorExists(cli, j.SubnetID, nodeID)
+		if err != nil {
+			return err
+		}
+		if !found {
+			color.Outf("{{yellow}}%s (tx %s) not found on subnet %s, adding it again{{/}}\n", nodeID, txID, j.SubnetID)
+			delete(j.SubnetValidators, nodeID)
+		}
+	}
+	if j.BlockchainID == ids.Empty {
+		return nil
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), requestTimeout)
+	status, err := cli.P().Client().GetBlockchainStatus(ctx, j.BlockchainID.String())
+	cancel()
+	if err != nil {
+		return err
+	}
+	if status == pstatus.UnknownChain {
+		color.Outf("{{yellow}}blockchain %s not found, creating it again{{/}}\n", j.BlockchainID)
+		j.BlockchainID = ids.Empty
+	}
+	return nil
+}
+
+// journalValidatorExists returns false only if [nodeID] is known not to
+// validate [subnetID], so that a failed request never repeats a paid step.
+func journalValidatorExists(cli client.Client, subnetID ids.ID, nodeID ids.NodeID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	_, err := cli.P().GetValidator(ctx, subnetID, nodeID)
+	cancel()
+	switch {
+	case errors.Is(err, client.ErrValidatorNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
@@ -4,12 +4,35 @@
 package avax
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
+const (
+	// MaxAddrs is the number of addresses sent per UTXO request.
+	// Nodes accept up to 1024 ("maxGetUTXOsAddrs"), but public
+	// API endpoints may reject large requests.
+	MaxAddrs = 256
+	// MaxPageSize is the maximum number of UTXOs returned per page.
+	// ref. "builder.MaxPageSize"
+	MaxPageSize = 1024
+)
+
+// GetUTXOsFunc fetches a page of at most [limit] UTXOs of [addrs],
+// starting after [startAddr] and [startUTXOID], and returns the index
+// of the last UTXO fetched.
+type GetUTXOsFunc func(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	limit uint32,
+	startAddr ids.ShortID,
+	startUTXOID ids.ID,
+) (ubs [][]byte, endAddr ids.ShortID, endUTXOID ids.ID, err error)
+
 func ParseUTXO(ub []byte, cd codec.Manager) (*avax.UTXO, error) {
 	utxo := new(avax.UTXO)
 	if _, err := cd.Unmarshal(ub, utxo); err != nil {
@@ -17,3 +40,50 @@ func ParseUTXO(ub []byte, cd codec.Manager) (*avax.UTXO, error) {
 	}
 	return utxo, nil
 }
+
+// GetAllUTXOs fetches all UTXOs of [addrs] with [get], splitting [addrs]
+// into chunks of [MaxAddrs] and following each page until exhaustion.
+func GetAllUTXOs(ctx context.Context, addrs []ids.ShortID, get GetUTXOsFunc, cd codec.Manager) ([]*avax.UTXO, error) {
+	seen := map[ids.ID]struct{}{}
+	utxos := []*avax.UTXO{}
+	for _, chunk := range ChunkAddrs(addrs, MaxAddrs) {
+		startAddr, startUTXOID := ids.ShortEmpty, ids.Empty
+		for {
+			ubs, endAddr, endUTXOID, err := get(ctx, chunk, MaxPageSize, startAddr, startUTXOID)
+			if err != nil {
+				return nil, err
+			}
+			for _, ub := range ubs {
+				utxo, err := ParseUTXO(ub, cd)
+				if err != nil {
+					return nil, err
+				}
+				// UTXOs owned by several addresses may be returned more than once
+				utxoID := utxo.InputID()
+				if _, ok := seen[utxoID]; ok {
+					continue
+				}
+				seen[utxoID] = struct{}{}
+				utxos = append(utxos, utxo)
+			}
+			if len(ubs) < MaxPageSize {
+				break
+			}
+			startAddr, startUTXOID = endAddr, endUTXOID
+		}
+	}
+	return utxos, nil
+}
+
+// ChunkAddrs splits [addrs] into chunks of at most [size] addresses.
+func ChunkAddrs(addrs []ids.ShortID, size int) [][]ids.ShortID {
+	chunks := make([][]ids.ShortID, 0, (len(addrs)+size-1)/size)
+	for start := 0; start < len(addrs); start += size {
+		end := start + size
+		if end > len(addrs) {
+			end = len(addrs)
+		}
+		chunks = append(chunks, addrs[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestChunkAddrs(t *testing.T) {
+	t.Parallel()
+
+	addrs := make([]ids.ShortID, 5)
+	for i := range addrs {
+		addrs[i] = ids.GenerateTestShortID()
+	}
+	chunks := ChunkAddrs(addrs, 2)
+	if len(chunks) != 3 {
+		t.Fatalf("unexpected chunks %d, expected 3", len(chunks))
+	}
+	if len(chunks[2]) != 1 || chunks[2][0] != addrs[4] {
+		t.Fatalf("unexpected last chunk %v", chunks[2])
+	}
+	if chunks := ChunkAddrs(nil, 2); len(chunks) != 0 {
+		t.Fatalf("unexpected chunks %d, expected 0", len(chunks))
+	}
+}
+
+func TestGetAllUTXOs(t *testing.T) {
+	t.Parallel()
+
+	addrs := make([]ids.ShortID, MaxAddrs+1)
+	for i := range addrs {
+		addrs[i] = ids.GenerateTestShortID()
+	}
+	// first chunk holds 1.5 pages of UTXOs, and the second chunk
+	// returns a UTXO of the first chunk again
+	ubs := make([][]byte, MaxPageSize*3/2)
+	for i := range ubs {
+		ubs[i] = testUTXO(t, uint32(i))
+	}
+
+	requests := 0
+	utxos, err := GetAllUTXOs(context.Background(), addrs, func(
+		_ context.Context,
+		chunk []ids.ShortID,
+		limit uint32,
+		startAddr ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		requests++
+		if len(chunk) > MaxAddrs {
+			t.Fatalf("unexpected chunk size %d", len(chunk))
+		}
+		if chunk[0] == addrs[MaxAddrs] {
+			return ubs[:1], chunk[0], ids.Empty, nil
+		}
+		if startAddr == ids.ShortEmpty {
+			return ubs[:limit], chunk[0], ids.GenerateTestID(), nil
+		}
+		return ubs[limit:], chunk[0], ids.Empty, nil
+	}, txs.Codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Fatalf("unexpected requests %d, expected 3", requests)
+	}
+	if len(utxos) != len(ubs) {
+		t.Fatalf("unexpected UTXOs %d, expected %d", len(utxos), len(ubs))
+	}
+}
+
+func testUTXO(t *testing.T, index uint32) []byte {
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{OutputIndex: index},
+		Asset:  avax.Asset{ID: ids.Empty},
+		Out:    &secp256k1fx.TransferOutput{Amt: 1},
+	}
+	ub, err := txs.Codec.Marshal(txs.Version, utxo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ub
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+var (
+	ErrInvalidBLSKey            = errors.New("invalid BLS key")
+	ErrInvalidProofOfPossession = errors.New("invalid BLS proof of possession")
+)
+
+// LoadProofOfPossession loads the BLS secret key of a node
+// (e.g., "~/.avalanchego/staking/signer.key") and returns its
+// public key and proof of possession.
+func LoadProofOfPossession(keyPath string) (*signer.ProofOfPossession, error) {
+	kb, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	sk, err := bls.SecretKeyFromBytes(kb)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBLSKey, err)
+	}
+	return signer.NewProofOfPossession(sk), nil
+}
+
+// ParseProofOfPossession parses the hex-encoded BLS public key and proof of
+// possession of a node (e.g., as returned by "info.getNodeID"), and
+// verifies the proof.
+func ParseProofOfPossession(publicKey string, proofOfPossession string) (*signer.ProofOfPossession, error) {
+	pop := new(signer.ProofOfPossession)
+	pkBytes, err := decodeHex(publicKey)
+	if err != nil || len(pkBytes) != bls.PublicKeyLen {
+		return nil, fmt.Errorf("%w: public key %q", ErrInvalidBLSKey, publicKey)
+	}
+	copy(pop.PublicKey[:], pkBytes)
+	sigBytes, err := decodeHex(proofOfPossession)
+	if err != nil || len(sigBytes) != bls.SignatureLen {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidProofOfPossession, proofOfPossession)
+	}
+	copy(pop.ProofOfPossession[:], sigBytes)
+	if err := pop.Verify(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProofOfPossession, err)
+	}
+	return pop, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	sk, err := bls.NewSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "signer.key")
+	if err := os.WriteFile(keyPath, bls.SecretKeyToBytes(sk), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pop, err := LoadProofOfPossession(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pop.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	pk := "0x" + hex.EncodeToString(pop.PublicKey[:])
+	sig := hex.EncodeToString(pop.ProofOfPossession[:])
+	pop2, err := ParseProofOfPossession(pk, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pop2.PublicKey != pop.PublicKey {
+		t.Fatalf("unexpected public key %x, expected %x", pop2.PublicKey, pop.PublicKey)
+	}
+
+	// proof of possession of another key
+	sk2, err := bls.NewSecretKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig2 := bls.SignatureToBytes(bls.SignProofOfPossession(sk2, pop.PublicKey[:]))
+	if _, err := ParseProofOfPossession(pk, hex.EncodeToString(sig2)); !errors.Is(err, ErrInvalidProofOfPossession) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidProofOfPossession)
+	}
+	if _, err := ParseProofOfPossession("0x1234", sig); !errors.Is(err, ErrInvalidBLSKey) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidBLSKey)
+	}
+}
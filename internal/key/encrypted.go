@@ -0,0 +1,155 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	encryptedKeyVersion = 1
+
+	kdfScrypt       = "scrypt"
+	cipherAES256GCM = "aes-256-gcm"
+
+	// ref. "go-ethereum/accounts/keystore.StandardScryptN"
+	scryptN       = 1 << 18
+	scryptR       = 8
+	scryptP       = 1
+	scryptKeyLen  = 32
+	scryptSaltLen = 32
+)
+
+var (
+	ErrMissingPassphrase   = errors.New("missing passphrase for encrypted key")
+	ErrInvalidPassphrase   = errors.New("invalid passphrase (or corrupted key file)")
+	ErrInvalidEncryptedKey = errors.New("invalid encrypted key")
+)
+
+// encryptedKey is the versioned JSON format of an encrypted key file.
+// The plaintext is the content of an unencrypted key file (i.e., the
+// hex-encoded private key, or the mnemonic).
+type encryptedKey struct {
+	Version    int          `json:"version"`
+	KDF        string       `json:"kdf"`
+	KDFParams  scryptParams `json:"kdfParams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+type scryptParams struct {
+	N      int    `json:"n"`
+	R      int    `json:"r"`
+	P      int    `json:"p"`
+	KeyLen int    `json:"keyLen"`
+	Salt   string `json:"salt"`
+}
+
+// isEncrypted returns true if the key file content [b] is an encrypted key.
+func isEncrypted(b []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(b), []byte("{"))
+}
+
+// encrypt encrypts [plaintext] with a key derived from [passphrase].
+func encrypt(plaintext []byte, passphrase []byte) ([]byte, error) {
+	salt := make([]byte, scryptSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	ek := &encryptedKey{
+		Version: encryptedKeyVersion,
+		KDF:     kdfScrypt,
+		KDFParams: scryptParams{
+			N:      scryptN,
+			R:      scryptR,
+			P:      scryptP,
+			KeyLen: scryptKeyLen,
+			Salt:   hex.EncodeToString(salt),
+		},
+		Cipher: cipherAES256GCM,
+	}
+	aead, err := ek.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ek.Nonce = hex.EncodeToString(nonce)
+	ek.Ciphertext = hex.EncodeToString(aead.Seal(nil, nonce, plaintext, nil))
+	return json.MarshalIndent(ek, "", "  ")
+}
+
+// decrypt decrypts the encrypted key file content [b] with [passphrase].
+func decrypt(b []byte, passphrase []byte) ([]byte, error) {
+	ek := new(encryptedKey)
+	if err := json.Unmarshal(b, ek); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptedKey, err)
+	}
+	switch {
+	case ek.Version != encryptedKeyVersion:
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidEncryptedKey, ek.Version)
+	case ek.KDF != kdfScrypt:
+		return nil, fmt.Errorf("%w: unsupported KDF %q", ErrInvalidEncryptedKey, ek.KDF)
+	case ek.Cipher != cipherAES256GCM:
+		return nil, fmt.Errorf("%w: unsupported cipher %q", ErrInvalidEncryptedKey, ek.Cipher)
+	case ek.KDFParams.N != scryptN || ek.KDFParams.R != scryptR || ek.KDFParams.P != scryptP:
+		// a tampered file could make scrypt exhaust the memory or CPU
+		return nil, fmt.Errorf("%w: unsupported scrypt parameters N=%d, r=%d, p=%d",
+			ErrInvalidEncryptedKey, ek.KDFParams.N, ek.KDFParams.R, ek.KDFParams.P)
+	case ek.KDFParams.KeyLen != scryptKeyLen:
+		return nil, fmt.Errorf("%w: unsupported key length %d", ErrInvalidEncryptedKey, ek.KDFParams.KeyLen)
+	}
+	nonce, err := hex.DecodeString(ek.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: nonce (%v)", ErrInvalidEncryptedKey, err)
+	}
+	ciphertext, err := hex.DecodeString(ek.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ciphertext (%v)", ErrInvalidEncryptedKey, err)
+	}
+	aead, err := ek.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: nonce length %d", ErrInvalidEncryptedKey, len(nonce))
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	return plaintext, nil
+}
+
+// aead derives the AES key from [passphrase] with the KDF parameters.
+func (ek *encryptedKey) aead(passphrase []byte) (cipher.AEAD, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrMissingPassphrase
+	}
+	salt, err := hex.DecodeString(ek.KDFParams.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: salt (%v)", ErrInvalidEncryptedKey, err)
+	}
+	dk, err := scrypt.Key(passphrase, salt, ek.KDFParams.N, ek.KDFParams.R, ek.KDFParams.P, ek.KDFParams.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptedKey, err)
+	}
+	block, err := aes.NewCipher(dk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncryptedKey, err)
+	}
+	return cipher.NewGCM(block)
+}
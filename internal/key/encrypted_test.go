@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptedKey(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.json")
+	if err := m.SaveEncrypted(keyPath, []byte("passphrase")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, m.Raw()) || bytes.Contains(b, m.fileContent()) {
+		t.Fatal("encrypted key file contains the private key")
+	}
+
+	if _, err := LoadSoft(fallbackNetworkID, keyPath); !errors.Is(err, ErrMissingPassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMissingPassphrase)
+	}
+	wrong := WithPassphrase(func() ([]byte, error) { return []byte("wrong"), nil })
+	if _, err := LoadSoft(fallbackNetworkID, keyPath, wrong); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidPassphrase)
+	}
+	m2, err := LoadSoft(fallbackNetworkID, keyPath, WithPassphrase(func() ([]byte, error) { return []byte("passphrase"), nil }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(m.Raw(), m2.Raw()) {
+		t.Fatalf("loaded key unexpected %v, expected %v", m2.Raw(), m.Raw())
+	}
+
+	// KDF parameters other than the ones written are rejected
+	// before deriving the key
+	for _, params := range []string{`"n": 262144`, `"r": 8`, `"p": 1`, `"keyLen": 32`} {
+		tampered := bytes.Replace(b, []byte(params), []byte(params+"0"), 1)
+		if bytes.Equal(tampered, b) {
+			t.Fatalf("%s not found in the encrypted key file", params)
+		}
+		if _, err := decrypt(tampered, []byte("passphrase")); !errors.Is(err, ErrInvalidEncryptedKey) {
+			t.Fatalf("%s: unexpected error %v, expected %v", params, err, ErrInvalidEncryptedKey)
+		}
+	}
+
+	// the passphrase is not asked for an unencrypted key
+	plainPath := filepath.Join(t.TempDir(), "key.pk")
+	if err := m.Save(plainPath); err != nil {
+		t.Fatal(err)
+	}
+	fail := WithPassphrase(func() ([]byte, error) { return nil, errors.New("unexpected prompt") })
+	if _, err := LoadSoft(fallbackNetworkID, plainPath, fail); err != nil {
+		t.Fatal(err)
+	}
+}
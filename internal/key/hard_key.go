@@ -4,9 +4,9 @@
 package key
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/ava-labs/subnet-cli/pkg/color"
 
@@ -16,22 +16,42 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/manifoldco/promptui"
 	"github.com/onsi/ginkgo/v2/formatter"
 	"go.uber.org/zap"
 )
 
-const (
-	numAddresses = 1024
-)
+// number of addresses derived without a gap-limit scan
+const numAddresses = 1024
 
 var _ Key = &HardKey{}
 
+// LedgerDevice is the part of the Ledger API that exports the extended
+// public key of an account and signs with its addresses, implemented
+// over HID and by SimulatedLedger.
+type LedgerDevice interface {
+	// ExtendedPublicKey returns the public key and chain code of
+	// "m/44'/9000'/[account]'/0", the parent of the account addresses.
+	ExtendedPublicKey(account uint32) (pk []byte, chainCode []byte, err error)
+	// SignHash signs [hash] with the key of each address index of [account].
+	SignHash(account uint32, hash []byte, addressIndices []uint32) ([][]byte, error)
+	Disconnect() error
+}
+
 type HardKey struct {
-	l ledger.Ledger
+	l       LedgerDevice
+	account uint32
+	// retry returns true to retry a failed ledger action
+	retry func(err error, fallback string) bool
+
+	// pk and chainCode are the extended public key of the account,
+	// which the addresses are derived from without the device
+	pk        []byte
+	chainCode []byte
 
 	pAddrs       []string
 	shortAddrs   []ids.ShortID
@@ -39,87 +59,232 @@ type HardKey struct {
 }
 
 func parseLedgerErr(err error, fallback string) {
-	errString := err.Error()
 	switch {
-	case strings.Contains(errString, "LedgerHID device") && strings.Contains(errString, "not found"):
+	case errors.Is(err, ledger.ErrLedgerNotConnected):
 		color.Outf("{{red}}ledger is not connected{{/}}\n")
-	case strings.Contains(errString, "6b0c"):
+	case errors.Is(err, ledger.ErrLedgerIsBlocked):
 		color.Outf("{{red}}ledger is not unlocked{{/}}\n")
-	case strings.Contains(errString, "APDU_CODE_CONDITIONS_NOT_SATISFIED"):
+	case errors.Is(err, ledger.ErrAvalancheAppNotExecuting):
+		color.Outf("{{red}}avalanche app is not open on ledger{{/}}\n")
+	case errors.Is(err, ledger.ErrRejectedSignature), errors.Is(err, ledger.ErrRejectedKeyProvide):
 		color.Outf("{{red}}ledger rejected signing{{/}}\n")
 	default:
 		color.Outf("{{red}}%s: %v{{/}}\n", fallback, err)
 	}
 }
 
+// promptRetry asks the user whether to retry a failed ledger action
+// (e.g., after unlocking their Ledger) instead of exiting.
+func promptRetry(rerr error, fallback string) bool {
+	parseLedgerErr(rerr, fallback)
+
+	color.Outf("\n{{cyan}}ledger action failed...what now?{{/}}\n")
+	prompt := promptui.Select{
+		Label:  "\n",
+		Stdout: os.Stdout,
+		Items: []string{
+			formatter.F("{{green}}retry{{/}}"),
+			formatter.F("{{red}}exit{{/}}"),
+		},
+	}
+	idx, _, err := prompt.Run()
+	return err == nil && idx == 0
+}
+
 // retriableLedgerAction wraps all Ledger calls to allow the user to try and
 // recover instead of exiting (in case their Ledger locks).
-func retriableLegerAction(f func() error, fallback string) error {
+func retriableLegerAction(retry func(error, string) bool, f func() error, fallback string) error {
 	for {
 		rerr := f()
 		if rerr == nil {
 			return nil
 		}
-		parseLedgerErr(rerr, fallback)
-
-		color.Outf("\n{{cyan}}ledger action failed...what now?{{/}}\n")
-		prompt := promptui.Select{
-			Label:  "\n",
-			Stdout: os.Stdout,
-			Items: []string{
-				formatter.F("{{green}}retry{{/}}"),
-				formatter.F("{{red}}exit{{/}}"),
-			},
-		}
-		idx, _, err := prompt.Run()
-		if err != nil || idx == 1 {
+		if !retry(rerr, fallback) {
 			return rerr
 		}
 	}
 }
 
-func NewHard(networkID uint32) (*HardKey, error) {
-	k := &HardKey{}
-	color.Outf("{{yellow}}connecting to ledger...{{/}}\n")
-	if err := retriableLegerAction(func() error {
-		l, err := ledger.New()
-		if err != nil {
-			return err
-		}
-		k.l = l
-		return nil
-	}, "failed to connect to ledger"); err != nil {
-		return nil, err
+type HOp struct {
+	device  LedgerDevice
+	retry   func(error, string) bool
+	account uint32
+
+	gapLimit      uint32
+	usedAddresses func([]ids.ShortID) ([]ids.ShortID, error)
+	cacheDir      string
+}
+
+type HOpOption func(*HOp)
+
+func (hop *HOp) applyOpts(opts []HOpOption) {
+	for _, opt := range opts {
+		opt(hop)
 	}
+}
 
-	color.Outf("{{yellow}}deriving address from ledger...{{/}}\n")
-	hrp := getHRP(networkID)
-	if err := retriableLegerAction(func() error {
-		addrs, err := k.l.Addresses(numAddresses)
-		if err != nil {
-			return err
-		}
-		laddrs := len(addrs)
-		k.pAddrs = make([]string, laddrs)
-		k.shortAddrs = make([]ids.ShortID, laddrs)
-		k.shortAddrMap = map[ids.ShortID]uint32{}
-		for i, addr := range addrs {
-			k.pAddrs[i], err = address.Format("P", hrp, addr[:])
+// To use [d] (e.g., a SimulatedLedger) instead of connecting to a Ledger.
+func WithDevice(d LedgerDevice) HOpOption {
+	return func(hop *HOp) {
+		hop.device = d
+	}
+}
+
+// To decide whether to retry failed ledger actions
+// without prompting (e.g., in tests).
+func WithRetry(retry func(err error, fallback string) bool) HOpOption {
+	return func(hop *HOp) {
+		hop.retry = retry
+	}
+}
+
+// To use the addresses of [account] ("m/44'/9000'/[account]'/0/i").
+func WithAccount(account uint32) HOpOption {
+	return func(hop *HOp) {
+		hop.account = account
+	}
+}
+
+// To derive addresses until [gap] consecutive ones are unused,
+// instead of deriving the first 1024. [used] returns the addresses
+// of its input that are used (e.g., own UTXOs on the P-Chain). If
+// [used] is nil (e.g., offline), the addresses of the last scan
+// cached in the cache directory are used.
+func WithGapLimit(gap uint32, used func(addrs []ids.ShortID) ([]ids.ShortID, error)) HOpOption {
+	return func(hop *HOp) {
+		hop.gapLimit = gap
+		hop.usedAddresses = used
+	}
+}
+
+// To cache the gap-limit scan in [dir], keyed by the
+// extended public key of the account.
+func WithCacheDir(dir string) HOpOption {
+	return func(hop *HOp) {
+		hop.cacheDir = dir
+	}
+}
+
+func NewHard(networkID uint32, opts ...HOpOption) (*HardKey, error) {
+	ret := &HOp{retry: promptRetry}
+	ret.applyOpts(opts)
+
+	k := &HardKey{l: ret.device, account: ret.account, retry: ret.retry}
+	if k.l == nil {
+		color.Outf("{{yellow}}connecting to ledger...{{/}}\n")
+		if err := retriableLegerAction(k.retry, func() error {
+			l, err := connectLedger()
 			if err != nil {
 				return err
 			}
-			k.shortAddrs[i] = addr
-			k.shortAddrMap[addr] = uint32(i)
+			k.l = l
+			return nil
+		}, "failed to connect to ledger"); err != nil {
+			return nil, err
 		}
-		return nil
+	}
+
+	color.Outf("{{yellow}}deriving addresses of account %d from ledger...{{/}}\n", k.account)
+	if err := retriableLegerAction(k.retry, func() (err error) {
+		k.pk, k.chainCode, err = k.l.ExtendedPublicKey(k.account)
+		return err
 	}, "failed to get extended public key"); err != nil {
 		return nil, err
 	}
 
-	color.Outf("{{yellow}}derived primary address from ledger: %s{{/}}\n", k.pAddrs[0])
+	cache, cached := loadLedgerCache(ret.cacheDir, k.pk, k.chainCode)
+	var derived []ids.ShortID
+	n := uint32(numAddresses)
+	var err error
+	switch {
+	case ret.gapLimit > 0 && ret.usedAddresses != nil:
+		derived, cache.Used, err = k.scan(derived, cache.Used, ret.gapLimit, ret.usedAddresses)
+		if err != nil {
+			return nil, err
+		}
+		n = cache.Used + ret.gapLimit
+	case ret.gapLimit > 0 && cached:
+		n = cache.Used + ret.gapLimit
+	}
+	derived, err = k.derive(derived, n)
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.save(ret.cacheDir, k.pk, k.chainCode); err != nil {
+		zap.L().Warn("failed to cache ledger scan", zap.Error(err))
+	}
+
+	hrp := getHRP(networkID)
+	k.pAddrs = make([]string, n)
+	k.shortAddrs = derived[:n]
+	k.shortAddrMap = make(map[ids.ShortID]uint32, n)
+	for i, addr := range k.shortAddrs {
+		k.pAddrs[i], err = address.Format("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		k.shortAddrMap[addr] = uint32(i)
+	}
+	color.Outf("{{yellow}}derived %d addresses from ledger, primary address: %s{{/}}\n", n, k.pAddrs[0])
 	return k, nil
 }
 
+// derive extends [derived] to the first [n] addresses of the account.
+func (h *HardKey) derive(derived []ids.ShortID, n uint32) ([]ids.ShortID, error) {
+	for i := uint32(len(derived)); i < n; i++ {
+		pk, err := ledger.NewChild(h.pk, h.chainCode, i)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := ids.ToShortID(hashing.PubkeyBytesToAddress(pk))
+		if err != nil {
+			return nil, err
+		}
+		derived = append(derived, addr)
+	}
+	return derived, nil
+}
+
+// scan derives addresses after the first [used] ones until [gap]
+// consecutive ones are unused, and returns the number of addresses
+// up to the last used one.
+func (h *HardKey) scan(
+	derived []ids.ShortID,
+	used uint32,
+	gap uint32,
+	usedAddresses func([]ids.ShortID) ([]ids.ShortID, error),
+) ([]ids.ShortID, uint32, error) {
+	for next := used; next < used+gap; {
+		end := used + gap
+		var err error
+		derived, err = h.derive(derived, end)
+		if err != nil {
+			return nil, 0, err
+		}
+		batch := derived[next:end]
+		usedAddrs, err := usedAddresses(batch)
+		if err != nil {
+			return nil, 0, err
+		}
+		usedSet := make(map[ids.ShortID]struct{}, len(usedAddrs))
+		for _, addr := range usedAddrs {
+			usedSet[addr] = struct{}{}
+		}
+		for i, addr := range batch {
+			if _, ok := usedSet[addr]; ok {
+				used = next + uint32(i) + 1
+			}
+		}
+		zap.L().Debug("scanned ledger addresses",
+			zap.Uint32("from", next),
+			zap.Uint32("to", end),
+			zap.Uint32("used", used),
+		)
+		next = end
+	}
+	return derived, used, nil
+}
+
 func (h *HardKey) Disconnect() error {
 	return h.l.Disconnect()
 }
@@ -135,146 +300,68 @@ func (h *HardKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	inputs []*avax.TransferableInput,
 	signers [][]ids.ShortID,
 ) {
-	ret := &Op{}
-	ret.applyOpts(opts)
-
-	for _, out := range outputs {
-		input, txsigners, err := h.spend(out, ret.time)
-		if err != nil {
-			zap.L().Warn("cannot spend with current key", zap.Error(err))
-			continue
-		}
-		totalBalanceToSpend += input.Amount()
-		inputs = append(inputs, &avax.TransferableInput{
-			UTXOID: out.UTXOID,
-			Asset:  out.Asset,
-			In:     input,
-		})
-		signers = append(signers, txsigners)
-		if ret.targetAmount > 0 &&
-			totalBalanceToSpend > ret.targetAmount+ret.feeDeduct {
-			break
-		}
-	}
-	SortTransferableInputsWithSigners(inputs, signers)
-	return totalBalanceToSpend, inputs, signers
-}
-
-func (h *HardKey) spend(output *avax.UTXO, time uint64) (
-	input avax.TransferableIn,
-	signers []ids.ShortID,
-	err error,
-) {
-	// "time" is used to check whether the key owner
-	// is still within the lock time (thus can't spend).
-	inputf, signers, err := h.lspend(output.Out, time)
-	if err != nil {
-		return nil, nil, err
-	}
-	var ok bool
-	input, ok = inputf.(avax.TransferableIn)
-	if !ok {
-		return nil, nil, ErrInvalidType
-	}
-	return input, signers, nil
-}
-
-func (h *HardKey) lspend(out verify.Verifiable, time uint64) (verify.Verifiable, []ids.ShortID, error) {
-	switch out := out.(type) {
-	case *secp256k1fx.MintOutput:
-		if sigIndices, signers, able := h.Match(&out.OutputOwners, time); able {
-			return &secp256k1fx.Input{
-				SigIndices: sigIndices,
-			}, signers, nil
-		}
-		return nil, nil, ErrCantSpend
-	case *secp256k1fx.TransferOutput:
-		if sigIndices, signers, able := h.Match(&out.OutputOwners, time); able {
-			return &secp256k1fx.TransferInput{
-				Amt: out.Amt,
-				Input: secp256k1fx.Input{
-					SigIndices: sigIndices,
-				},
-			}, signers, nil
-		}
-		return nil, nil, ErrCantSpend
-	}
-	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+	return spendUTXOs(h, outputs, opts)
 }
 
 func (h *HardKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
-	if time < owners.Locktime {
-		return nil, nil, false
-	}
-	sigs := make([]uint32, 0, owners.Threshold)
-	signers := make([]ids.ShortID, 0, owners.Threshold)
-	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(sigs)) < owners.Threshold; i++ {
-		if _, ok := h.shortAddrMap[owners.Addrs[i]]; ok {
-			sigs = append(sigs, i)
-			signers = append(signers, owners.Addrs[i])
-		}
-	}
-	return sigs, signers, uint32(len(sigs)) == owners.Threshold
+	return matchOwners(h.shortAddrMap, owners, time)
 }
 
 // Sign transaction with the Ledger private key
 //
 // This is a slightly modified version of *platformvm.Tx.Sign().
 func (h *HardKey) Sign(pTx *txs.Tx, signers [][]ids.ShortID) error {
-	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &pTx.Unsigned)
+	unsignedBytes, hash, err := UnsignedHash(pTx)
 	if err != nil {
-		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+		return err
 	}
-	hash := hashing.ComputeHash256(unsignedBytes)
 
 	// Generate signature
-	uniqueSigners := map[uint32]struct{}{}
-	for _, inputSigners := range signers {
-		for _, signer := range inputSigners {
-			if v, ok := h.shortAddrMap[signer]; ok {
-				uniqueSigners[v] = struct{}{}
-			} else {
-				// Should never happen
-				return ErrCantSpend
-			}
-		}
+	sigs, err := SignSigners(h, hash, signers)
+	if err != nil {
+		return err
 	}
-	indices := make([]uint32, 0, len(uniqueSigners))
-	for idx := range uniqueSigners {
-		indices = append(indices, idx)
+
+	// Add credentials to transaction
+	return AttachCredentials(pTx, unsignedBytes, signers, sigs)
+}
+
+// SignHash signs [hash] with the Ledger private key of each of [signers].
+func (h *HardKey) SignHash(hash []byte, signers []ids.ShortID) ([][]byte, error) {
+	indices := make([]uint32, len(signers))
+	for i, signer := range signers {
+		v, ok := h.shortAddrMap[signer]
+		if !ok {
+			// Should never happen
+			return nil, ErrCantSpend
+		}
+		indices[i] = v
 	}
 
 	var sigs [][]byte
-	if err := retriableLegerAction(func() error {
-		sigs, err = h.l.SignHash(hash, indices)
-		if err != nil {
-			return err
-		}
-		return nil
+	if err := retriableLegerAction(h.retry, func() (err error) {
+		sigs, err = h.l.SignHash(h.account, hash, indices)
+		return err
 	}, "failed to sign hash"); err != nil {
-		return fmt.Errorf("problem generating signatures: %w", err)
-	}
-	sigMap := map[ids.ShortID][]byte{}
-	for i, idx := range indices {
-		sigMap[h.shortAddrs[idx]] = sigs[i]
+		return nil, fmt.Errorf("problem generating signatures: %w", err)
 	}
+	return sigs, nil
+}
 
-	// Add credentials to transaction
-	for _, inputSigners := range signers {
-		cred := &secp256k1fx.Credential{
-			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
-		}
-		for i, signer := range inputSigners {
-			copy(cred.Sigs[i][:], sigMap[signer])
-		}
-		pTx.Creds = append(pTx.Creds, cred)
+// EthAddress returns the C-Chain address of the primary ledger address,
+// derived from the extended public key of the account.
+func (h *HardKey) EthAddress() (common.Address, error) {
+	b, err := ledger.NewChild(h.pk, h.chainCode, 0)
+	if err != nil {
+		return common.Address{}, err
 	}
-
-	// Create signed tx bytes
-	signedBytes, err := txs.Codec.Marshal(txs.Version, pTx)
+	pk, err := keyFactory.ToPublicKey(b)
 	if err != nil {
-		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
+		return common.Address{}, err
+	}
+	pubKey, ok := pk.(*crypto.PublicKeySECP256K1R)
+	if !ok {
+		return common.Address{}, ErrInvalidType
 	}
-	pTx.Initialize(unsignedBytes, signedBytes)
-	return nil
+	return ethcrypto.PubkeyToAddress(*pubKey.ToECDSA()), nil
 }
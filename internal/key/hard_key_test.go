@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	ledger "github.com/ava-labs/avalanche-ledger-go"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestHardKeySimulated(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim, err := NewSimulatedLedger(mnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retries := 0
+	h, err := NewHard(fallbackNetworkID, WithDevice(sim), WithRetry(func(error, string) bool {
+		retries++
+		return false
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the ledger derives the same addresses as the mnemonic
+	privKeys, err := DerivePrivateKeys(mnemonic, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Addresses()) != numAddresses {
+		t.Fatalf("unexpected %d addresses, expected %d", len(h.Addresses()), numAddresses)
+	}
+	for i, pk := range privKeys {
+		if h.Addresses()[i] != pk.PublicKey().Address() {
+			t.Fatalf("unexpected address %d %s, expected %s", i, h.Addresses()[i], pk.PublicKey().Address())
+		}
+	}
+
+	// owners must be sorted to be valid
+	multisig := []ids.ShortID{h.Addresses()[1], ids.GenerateTestShortID(), h.Addresses()[5]}
+	ids.SortShortIDs(multisig)
+
+	assetID := ids.GenerateTestID()
+	utxos := []*avax.UTXO{
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{h.Addresses()[3]},
+				},
+			},
+		},
+		{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID(), OutputIndex: 1},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 2000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 2,
+					Addrs:     multisig,
+				},
+			},
+		},
+		{
+			// can't be spent by the ledger
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 3000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				},
+			},
+		},
+	}
+	total, ins, signers := h.Spends(utxos)
+	if total != 3000 || len(ins) != 2 {
+		t.Fatalf("unexpected spend of %d with %d inputs", total, len(ins))
+	}
+
+	pTx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    fallbackNetworkID,
+				BlockchainID: ids.GenerateTestID(),
+				Ins:          ins,
+			}},
+			Owner: &secp256k1fx.OutputOwners{},
+		},
+	}
+	if err := h.Sign(pTx, signers); err != nil {
+		t.Fatal(err)
+	}
+
+	// the credentials must pass the signature verification of the P-Chain
+	fx := &secp256k1fx.Fx{}
+	if err := fx.Initialize(&secp256k1fx.TestVM{Codec: linearcodec.NewDefault(), Log: logging.NoLog{}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fx.Bootstrapped(); err != nil {
+		t.Fatal(err)
+	}
+	outs := map[ids.ID]*avax.UTXO{}
+	for _, utxo := range utxos {
+		outs[utxo.InputID()] = utxo
+	}
+	if len(pTx.Creds) != len(ins) {
+		t.Fatalf("unexpected %d credentials, expected %d", len(pTx.Creds), len(ins))
+	}
+	for i, in := range ins {
+		if err := fx.VerifyTransfer(pTx.Unsigned, in.In, pTx.Creds[i], outs[in.InputID()].Out); err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+
+	// a failed ledger action is retried only if [retry] allows it
+	if err := h.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.SignHash(hashing.ComputeHash256([]byte("hash")), h.Addresses()[:1]); !errors.Is(err, ledger.ErrLedgerNotConnected) {
+		t.Fatalf("unexpected error %v, expected %v", err, ledger.ErrLedgerNotConnected)
+	}
+	if retries != 1 {
+		t.Fatalf("unexpected %d retries, expected 1", retries)
+	}
+
+	// the C-Chain address is derived without the device
+	ethAddr, err := h.EthAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := ethcrypto.PubkeyToAddress(privKeys[0].ToECDSA().PublicKey); ethAddr != expected {
+		t.Fatalf("unexpected C-Chain address %s, expected %s", ethAddr, expected)
+	}
+}
+
+func TestHardKeyGapLimit(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim, err := NewSimulatedLedger(mnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coin, err := deriveCoinKey(mnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := deriveAccountKey(coin, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKeys := map[uint32]*crypto.PrivateKeySECP256K1R{}
+	for _, i := range []uint32{2, 7} {
+		privKeys[i], err = deriveChildKey(account, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// addresses 2 and 7 own UTXOs
+	var scanned [][]ids.ShortID
+	used := func(addrs []ids.ShortID) ([]ids.ShortID, error) {
+		scanned = append(scanned, addrs)
+		found := []ids.ShortID{}
+		for _, addr := range addrs {
+			for _, pk := range privKeys {
+				if pk.PublicKey().Address() == addr {
+					found = append(found, addr)
+				}
+			}
+		}
+		return found, nil
+	}
+	cacheDir := t.TempDir()
+	h, err := NewHard(
+		fallbackNetworkID,
+		WithDevice(sim),
+		WithAccount(1),
+		WithGapLimit(5, used),
+		WithCacheDir(cacheDir),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// [0, 5), then [5, 8) after finding 2, then [8, 13) after finding 7
+	if len(scanned) != 3 || len(scanned[0]) != 5 || len(scanned[1]) != 3 || len(scanned[2]) != 5 {
+		t.Fatalf("unexpected scans %v", scanned)
+	}
+	if len(h.Addresses()) != 13 {
+		t.Fatalf("unexpected %d addresses, expected 13", len(h.Addresses()))
+	}
+	if h.Addresses()[7] != privKeys[7].PublicKey().Address() {
+		t.Fatalf("unexpected address %s, expected %s", h.Addresses()[7], privKeys[7].PublicKey().Address())
+	}
+
+	// signs with the keys of the account
+	hash := hashing.ComputeHash256([]byte("hash"))
+	sigs, err := h.SignHash(hash, []ids.ShortID{h.Addresses()[7]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := keyFactory.RecoverHashPublicKey(hash, sigs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Address() != h.Addresses()[7] {
+		t.Fatalf("unexpected signer %s, expected %s", pk.Address(), h.Addresses()[7])
+	}
+
+	// the cached scan is used offline, and resumed online
+	scanned = nil
+	h2, err := NewHard(fallbackNetworkID, WithDevice(sim), WithAccount(1), WithGapLimit(5, nil), WithCacheDir(cacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h2.Addresses()) != 13 || len(scanned) != 0 {
+		t.Fatalf("unexpected %d addresses after %d scans", len(h2.Addresses()), len(scanned))
+	}
+	h3, err := NewHard(fallbackNetworkID, WithDevice(sim), WithAccount(1), WithGapLimit(5, used), WithCacheDir(cacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h3.Addresses()) != 13 || len(scanned) != 1 || scanned[0][0] != h.Addresses()[8] {
+		t.Fatalf("unexpected %d addresses after scans %v", len(h3.Addresses()), scanned)
+	}
+
+	// a tampered cache can't replace the derived addresses
+	cachePath := ledgerCachePath(cacheDir, h.pk, h.chainCode)
+	if err := os.WriteFile(cachePath, []byte(fmt.Sprintf(`{"used":8,"addresses":[%q]}`, ids.GenerateTestShortID())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	h5, err := NewHard(fallbackNetworkID, WithDevice(sim), WithAccount(1), WithGapLimit(5, nil), WithCacheDir(cacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h5.Addresses()) != 13 || h5.Addresses()[0] != h.Addresses()[0] {
+		t.Fatalf("unexpected addresses %v from a tampered cache", h5.Addresses())
+	}
+
+	// account 0 derives other addresses
+	h4, err := NewHard(fallbackNetworkID, WithDevice(sim), WithGapLimit(5, used), WithCacheDir(cacheDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h4.Addresses()) != 5 || h4.Addresses()[0] == h.Addresses()[0] {
+		t.Fatalf("unexpected account 0 addresses %v", h4.Addresses())
+	}
+}
@@ -7,18 +7,25 @@ package key
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
 )
 
 var (
-	ErrInvalidType = errors.New("invalid type")
-	ErrCantSpend   = errors.New("can't spend")
+	ErrInvalidType      = errors.New("invalid type")
+	ErrCantSpend        = errors.New("can't spend")
+	ErrMissingSignature = errors.New("missing signature")
 )
 
 // Key defines methods for key manager interface.
@@ -42,6 +49,11 @@ type Key interface {
 	)
 	// Sign generates [numSigs] signatures and attaches them to [pTx].
 	Sign(pTx *txs.Tx, signers [][]ids.ShortID) error
+	// SignHash signs [hash] with each of [signers] and returns
+	// the signatures in the same order.
+	SignHash(hash []byte, signers []ids.ShortID) ([][]byte, error)
+	// EthAddress returns the C-Chain address of the primary key.
+	EthAddress() (common.Address, error)
 }
 
 type Op struct {
@@ -78,6 +90,87 @@ func WithFeeDeduct(fee uint64) OpOption {
 	}
 }
 
+// spendOutput returns the input that spends [out] with the addresses
+// of [k] matched by "Match", and its signers.
+func spendOutput(k Key, out verify.Verifiable, time uint64) (verify.Verifiable, []ids.ShortID, error) {
+	switch out := out.(type) {
+	case *secp256k1fx.MintOutput:
+		if sigIndices, signers, able := k.Match(&out.OutputOwners, time); able {
+			return &secp256k1fx.Input{
+				SigIndices: sigIndices,
+			}, signers, nil
+		}
+		return nil, nil, ErrCantSpend
+	case *secp256k1fx.TransferOutput:
+		if sigIndices, signers, able := k.Match(&out.OutputOwners, time); able {
+			return &secp256k1fx.TransferInput{
+				Amt: out.Amt,
+				Input: secp256k1fx.Input{
+					SigIndices: sigIndices,
+				},
+			}, signers, nil
+		}
+		return nil, nil, ErrCantSpend
+	}
+	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+// spendUTXOs spends [outputs] with the addresses of [k] matched by
+// "Match" until the target amount of [opts] (if any) is reached, and
+// returns the sorted inputs and their signers.
+func spendUTXOs(k Key, outputs []*avax.UTXO, opts []OpOption) (
+	totalBalanceToSpend uint64,
+	inputs []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+) {
+	ret := &Op{}
+	ret.applyOpts(opts)
+
+	for _, out := range outputs {
+		// "time" is used to check whether the key owner
+		// is still within the lock time (thus can't spend).
+		inputf, txsigners, err := spendOutput(k, out.Out, ret.time)
+		if err != nil {
+			zap.L().Warn("cannot spend with current key", zap.Error(err))
+			continue
+		}
+		input, ok := inputf.(avax.TransferableIn)
+		if !ok {
+			continue
+		}
+		totalBalanceToSpend += input.Amount()
+		inputs = append(inputs, &avax.TransferableInput{
+			UTXOID: out.UTXOID,
+			Asset:  out.Asset,
+			In:     input,
+		})
+		signers = append(signers, txsigners)
+		if ret.targetAmount > 0 &&
+			totalBalanceToSpend > ret.targetAmount+ret.feeDeduct {
+			break
+		}
+	}
+	SortTransferableInputsWithSigners(inputs, signers)
+	return totalBalanceToSpend, inputs, signers
+}
+
+// matchOwners matches [owners] with the addresses in [addrs] up to
+// their threshold, and returns the signature indices and signers.
+func matchOwners[V any](addrs map[ids.ShortID]V, owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
+	if time < owners.Locktime {
+		return nil, nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	signers := make([]ids.ShortID, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(sigs)) < owners.Threshold; i++ {
+		if _, ok := addrs[owners.Addrs[i]]; ok {
+			sigs = append(sigs, i)
+			signers = append(signers, owners.Addrs[i])
+		}
+	}
+	return sigs, signers, uint32(len(sigs)) == owners.Threshold
+}
+
 func getHRP(networkID uint32) string {
 	switch networkID {
 	case constants.LocalID:
@@ -122,3 +215,103 @@ func (ins *innerSortTransferableInputsWithSigners) Swap(i, j int) {
 func SortTransferableInputsWithSigners(ins []*avax.TransferableInput, signers [][]ids.ShortID) {
 	sort.Sort(&innerSortTransferableInputsWithSigners{ins: ins, signers: signers})
 }
+
+// UnsignedHash returns the unsigned bytes of [pTx] and their hash,
+// which is what each signer signs.
+func UnsignedHash(pTx *txs.Tx) (unsignedBytes []byte, hash []byte, err error) {
+	unsignedBytes, err = txs.Codec.Marshal(txs.Version, &pTx.Unsigned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	return unsignedBytes, hashing.ComputeHash256(unsignedBytes), nil
+}
+
+// SignSigners signs [hash] once with each unique address in [signers]
+// and returns the signatures keyed by address.
+func SignSigners(k Key, hash []byte, signers [][]ids.ShortID) (map[ids.ShortID][]byte, error) {
+	uniqueSigners := map[ids.ShortID]struct{}{}
+	addrs := []ids.ShortID{}
+	for _, inputSigners := range signers {
+		for _, signer := range inputSigners {
+			if _, ok := uniqueSigners[signer]; ok {
+				continue
+			}
+			uniqueSigners[signer] = struct{}{}
+			addrs = append(addrs, signer)
+		}
+	}
+	sigs, err := k.SignHash(hash, addrs)
+	if err != nil {
+		return nil, err
+	}
+	sigMap := make(map[ids.ShortID][]byte, len(addrs))
+	for i, addr := range addrs {
+		sigMap[addr] = sigs[i]
+	}
+	return sigMap, nil
+}
+
+// Credentials returns a credential for each list of [signers]
+// using the signatures in [sigs].
+func Credentials(signers [][]ids.ShortID, sigs map[ids.ShortID][]byte) ([]*secp256k1fx.Credential, error) {
+	creds := make([]*secp256k1fx.Credential, len(signers))
+	for i, inputSigners := range signers {
+		cred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(inputSigners)),
+		}
+		for j, signer := range inputSigners {
+			sig, ok := sigs[signer]
+			if !ok {
+				return nil, fmt.Errorf("%w for %s", ErrMissingSignature, signer)
+			}
+			copy(cred.Sigs[j][:], sig)
+		}
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+// Signers recovers the addresses that signed [unsignedBytes]
+// for each credential of a transaction.
+func Signers(unsignedBytes []byte, creds []*secp256k1fx.Credential) ([][]ids.ShortID, error) {
+	hash := hashing.ComputeHash256(unsignedBytes)
+	signers := make([][]ids.ShortID, len(creds))
+	for i, cred := range creds {
+		signers[i] = make([]ids.ShortID, len(cred.Sigs))
+		for j, sig := range cred.Sigs {
+			pk, err := keyFactory.RecoverHashPublicKey(hash, sig[:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover signer of credential %d: %w", i, err)
+			}
+			signers[i][j] = pk.Address()
+		}
+	}
+	return signers, nil
+}
+
+// AttachCredentials adds a credential to [pTx] for each list of [signers]
+// using the signatures in [sigs], and initializes [pTx] with its signed bytes.
+//
+// This is a slightly modified version of *platformvm.Tx.Sign().
+func AttachCredentials(
+	pTx *txs.Tx,
+	unsignedBytes []byte,
+	signers [][]ids.ShortID,
+	sigs map[ids.ShortID][]byte,
+) error {
+	creds, err := Credentials(signers, sigs)
+	if err != nil {
+		return err
+	}
+	for _, cred := range creds {
+		pTx.Creds = append(pTx.Creds, cred)
+	}
+
+	// Create signed tx bytes
+	signedBytes, err := txs.Codec.Marshal(txs.Version, pTx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal ProposalTx: %w", err)
+	}
+	pTx.Initialize(unsignedBytes, signedBytes)
+	return nil
+}
@@ -9,12 +9,16 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/cb58"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 const (
 	ewoqPChainAddr    = "P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"
+	ewoqCChainAddr    = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
 	fallbackNetworkID = 999999 // unaffiliated networkID should trigger HRP Fallback
 )
 
@@ -116,3 +120,82 @@ func TestNewKey(t *testing.T) {
 		}
 	}
 }
+
+func TestSoftKeySignHash(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := m.Addresses()[0]
+	signers := [][]ids.ShortID{{addr}, {addr, addr}}
+
+	// signing with the key directly must match the credentials
+	// assembled from signatures collected one address at a time
+	expected := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	if err := m.Sign(expected, signers); err != nil {
+		t.Fatal(err)
+	}
+
+	pTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	unsignedBytes, hash, err := UnsignedHash(pTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AttachCredentials(pTx, unsignedBytes, signers, nil); !errors.Is(err, ErrMissingSignature) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMissingSignature)
+	}
+	pTx.Creds = nil
+
+	sigs, err := m.SignHash(hash, []ids.ShortID{addr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AttachCredentials(pTx, unsignedBytes, signers, map[ids.ShortID][]byte{addr: sigs[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if pTx.ID() != expected.ID() {
+		t.Fatalf("unexpected tx ID %s, expected %s", pTx.ID(), expected.ID())
+	}
+
+	if _, err := m.SignHash(hash, []ids.ShortID{ids.GenerateTestShortID()}); !errors.Is(err, ErrCantSpend) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrCantSpend)
+	}
+
+	// the signers are recovered from the credentials
+	creds := make([]*secp256k1fx.Credential, len(pTx.Creds))
+	for i, cred := range pTx.Creds {
+		creds[i] = cred.(*secp256k1fx.Credential)
+	}
+	recovered, err := Signers(unsignedBytes, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recovered) != len(signers) {
+		t.Fatalf("unexpected credentials %d, expected %d", len(recovered), len(signers))
+	}
+	for i := range signers {
+		for j := range signers[i] {
+			if recovered[i][j] != signers[i][j] {
+				t.Fatalf("unexpected signer %s, expected %s", recovered[i][j], signers[i][j])
+			}
+		}
+	}
+}
+
+func TestSoftKeyEthAddress(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := m.EthAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr.Hex() != ewoqCChainAddr {
+		t.Fatalf("unexpected C-Chain address %q, expected %q", addr.Hex(), ewoqCChainAddr)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrNoKeys = errors.New("no keys in keychain")
+
+var _ Key = &Keychain{}
+
+// Keychain aggregates several keys (e.g., soft keys and a ledger) into
+// one, so that outputs owned by several of them (e.g., a 2-of-3 subnet
+// owner) can be spent and signed with all of them at once.
+type Keychain struct {
+	keys []Key
+
+	pAddrs     []string
+	shortAddrs []ids.ShortID
+	// keyMap is the key that signs for each address
+	keyMap map[ids.ShortID]Key
+}
+
+// NewKeychain creates a keychain of [keys], whose first address is
+// the primary address of the first key.
+func NewKeychain(keys ...Key) (*Keychain, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	kc := &Keychain{
+		keys:   keys,
+		keyMap: map[ids.ShortID]Key{},
+	}
+	for _, k := range keys {
+		pAddrs := k.P()
+		for i, addr := range k.Addresses() {
+			if _, ok := kc.keyMap[addr]; ok {
+				// the same address is held by several keys, sign with the first
+				continue
+			}
+			kc.keyMap[addr] = k
+			kc.shortAddrs = append(kc.shortAddrs, addr)
+			kc.pAddrs = append(kc.pAddrs, pAddrs[i])
+		}
+	}
+	return kc, nil
+}
+
+// Keys returns the keys of the keychain.
+func (kc *Keychain) Keys() []Key { return kc.keys }
+
+func (kc *Keychain) P() []string { return kc.pAddrs }
+
+func (kc *Keychain) Addresses() []ids.ShortID { return kc.shortAddrs }
+
+func (kc *Keychain) Spends(outputs []*avax.UTXO, opts ...OpOption) (
+	totalBalanceToSpend uint64,
+	inputs []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+) {
+	return spendUTXOs(kc, outputs, opts)
+}
+
+// Match matches [owners] with the addresses of all the keys,
+// so a threshold can be met by several keys.
+func (kc *Keychain) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
+	return matchOwners(kc.keyMap, owners, time)
+}
+
+// Sign signs [pTx] with the key of each of [signers].
+func (kc *Keychain) Sign(pTx *txs.Tx, signers [][]ids.ShortID) error {
+	unsignedBytes, hash, err := UnsignedHash(pTx)
+	if err != nil {
+		return err
+	}
+	sigs, err := SignSigners(kc, hash, signers)
+	if err != nil {
+		return err
+	}
+	return AttachCredentials(pTx, unsignedBytes, signers, sigs)
+}
+
+// SignHash routes each of [signers] to the key that holds it, so each
+// key (e.g., a ledger) is asked to sign once.
+func (kc *Keychain) SignHash(hash []byte, signers []ids.ShortID) ([][]byte, error) {
+	keySigners := map[Key][]ids.ShortID{}
+	keyIndices := map[Key][]int{}
+	for i, signer := range signers {
+		k, ok := kc.keyMap[signer]
+		if !ok {
+			return nil, fmt.Errorf("%w (%s not in keychain)", ErrCantSpend, signer)
+		}
+		keySigners[k] = append(keySigners[k], signer)
+		keyIndices[k] = append(keyIndices[k], i)
+	}
+
+	sigs := make([][]byte, len(signers))
+	// sign in the order of the keys, for a deterministic ledger prompt order
+	for _, k := range kc.keys {
+		if len(keySigners[k]) == 0 {
+			continue
+		}
+		ksigs, err := k.SignHash(hash, keySigners[k])
+		if err != nil {
+			return nil, err
+		}
+		for j, idx := range keyIndices[k] {
+			sigs[idx] = ksigs[j]
+		}
+	}
+	return sigs, nil
+}
+
+// EthAddress returns the C-Chain address of the first key.
+func (kc *Keychain) EthAddress() (common.Address, error) {
+	return kc.keys[0].EthAddress()
+}
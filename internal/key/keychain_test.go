@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestKeychain(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewKeychain(); !errors.Is(err, ErrNoKeys) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrNoKeys)
+	}
+
+	ewoq, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewSoft(fallbackNetworkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc, err := NewKeychain(ewoq, other, ewoq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kc.Addresses()) != 2 || kc.P()[0] != ewoqPChainAddr {
+		t.Fatalf("unexpected addresses %v", kc.P())
+	}
+
+	// a 2-of-3 output can only be spent with both keys
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{ewoq.Addresses()[0], other.Addresses()[0], ids.GenerateTestShortID()},
+	}
+	ids.SortShortIDs(owners.Addrs)
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		Out:    &secp256k1fx.TransferOutput{Amt: 1000, OutputOwners: owners},
+	}
+	if total, _, _ := ewoq.Spends([]*avax.UTXO{utxo}); total != 0 {
+		t.Fatalf("unexpected spendable %d with a single key", total)
+	}
+	total, ins, signers := kc.Spends([]*avax.UTXO{utxo})
+	if total != 1000 || len(ins) != 1 || len(signers[0]) != 2 {
+		t.Fatalf("unexpected spend %d %v %v", total, ins, signers)
+	}
+
+	pTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	if err := kc.Sign(pTx, signers); err != nil {
+		t.Fatal(err)
+	}
+	unsignedBytes, _, err := UnsignedHash(pTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := Signers(unsignedBytes, []*secp256k1fx.Credential{pTx.Creds[0].(*secp256k1fx.Credential)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, signer := range signers[0] {
+		if recovered[0][i] != signer {
+			t.Fatalf("unexpected signer %s, expected %s", recovered[0][i], signer)
+		}
+	}
+
+	if _, err := kc.SignHash(make([]byte, 32), owners.Addrs); !errors.Is(err, ErrCantSpend) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrCantSpend)
+	}
+}
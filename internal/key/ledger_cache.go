@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"go.uber.org/zap"
+)
+
+const (
+	ledgerCacheDirMode  = 0o700
+	ledgerCacheFileMode = 0o600
+
+	// bounds the addresses derived from a cache file
+	maxLedgerCacheUsed = 1 << 16
+)
+
+// ledgerCache is the result of the last gap-limit scan of a Ledger
+// account, so that it isn't scanned again on every invocation. The
+// addresses themselves are always derived from the extended public
+// key, so that a tampered cache can't replace them.
+type ledgerCache struct {
+	// Used is the number of addresses up to the last
+	// one found used by a gap-limit scan.
+	Used uint32 `json:"used"`
+}
+
+// ledgerCachePath returns the cache file of the extended public key,
+// named by its hash so that the key itself isn't written to disk.
+func ledgerCachePath(dir string, pk []byte, chainCode []byte) string {
+	b := make([]byte, 0, len(pk)+len(chainCode))
+	b = append(b, pk...)
+	b = append(b, chainCode...)
+	return filepath.Join(dir, hex.EncodeToString(hashing.ComputeHash256(b))+".json")
+}
+
+// loadLedgerCache returns the cached scan and true, or an
+// empty cache and false if [dir] is empty or nothing is cached.
+func loadLedgerCache(dir string, pk []byte, chainCode []byte) (*ledgerCache, bool) {
+	c := new(ledgerCache)
+	if dir == "" {
+		return c, false
+	}
+	b, err := os.ReadFile(ledgerCachePath(dir, pk, chainCode))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			zap.L().Warn("failed to read ledger address cache", zap.Error(err))
+		}
+		return c, false
+	}
+	if err := json.Unmarshal(b, c); err != nil || c.Used > maxLedgerCacheUsed {
+		zap.L().Warn("ignoring invalid ledger address cache", zap.Error(err))
+		return new(ledgerCache), false
+	}
+	return c, true
+}
+
+func (c *ledgerCache) save(dir string, pk []byte, chainCode []byte) error {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, ledgerCacheDirMode); err != nil {
+		return err
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ledgerCachePath(dir, pk, chainCode), b, ledgerCacheFileMode)
+}
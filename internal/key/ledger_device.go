@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	ledger "github.com/ava-labs/avalanche-ledger-go"
+	ledger_go "github.com/zondax/ledger-go"
+)
+
+// APDUs of the Avalanche Ledger app.
+//
+// ref. https://github.com/ava-labs/avalanche-ledger-go
+const (
+	ledgerCLA                   = 0x80
+	ledgerINSPromptExtPublicKey = 0x03
+	ledgerINSSignHash           = 0x04
+
+	// maximum depth of a BIP32 path accepted by the app
+	maxBIP32Depth = 10
+)
+
+var _ LedgerDevice = &avaxLedger{}
+
+// avaxLedger talks to the Avalanche Ledger app like avalanche-ledger-go,
+// on the external chain of any account ("m/44'/9000'/[account]'/0")
+// instead of account 0 only.
+type avaxLedger struct {
+	device ledger_go.LedgerDevice
+}
+
+// connectLedger connects to the first Ledger over HID.
+func connectLedger() (*avaxLedger, error) {
+	device, err := ledger_go.NewLedgerAdmin().Connect(0)
+	if err != nil {
+		return nil, mapLedgerErr(err, ledger.ErrRejectedKeyProvide)
+	}
+	return &avaxLedger{device: device}, nil
+}
+
+func (l *avaxLedger) ExtendedPublicKey(account uint32) ([]byte, []byte, error) {
+	pathBytes, err := bip32Bytes(accountPath(account), 3)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := []byte{ledgerCLA, ledgerINSPromptExtPublicKey, 0x0, 0x0, byte(len(pathBytes))}
+	msg = append(msg, pathBytes...)
+	resp, err := l.device.Exchange(msg)
+	if err != nil {
+		return nil, nil, mapLedgerErr(err, ledger.ErrRejectedKeyProvide)
+	}
+	// [pk length][pk][chain code length][chain code]
+	if len(resp) < 1 {
+		return nil, nil, fmt.Errorf("%w: empty extended public key", ErrInvalidType)
+	}
+	pkLen := int(resp[0])
+	if len(resp) < 2+pkLen {
+		return nil, nil, fmt.Errorf("%w: extended public key of %d bytes", ErrInvalidType, len(resp))
+	}
+	chainCodeLen := int(resp[1+pkLen])
+	if len(resp) < 2+pkLen+chainCodeLen {
+		return nil, nil, fmt.Errorf("%w: extended public key of %d bytes", ErrInvalidType, len(resp))
+	}
+	return resp[1 : 1+pkLen], resp[2+pkLen : 2+pkLen+chainCodeLen], nil
+}
+
+func (l *avaxLedger) SignHash(account uint32, hash []byte, addressIndices []uint32) ([][]byte, error) {
+	pathBytes, err := bip32Bytes(accountPath(account), 3)
+	if err != nil {
+		return nil, err
+	}
+	data := []byte{byte(len(addressIndices))}
+	data = append(data, hash...)
+	data = append(data, pathBytes...)
+	msg := []byte{ledgerCLA, ledgerINSSignHash, 0x0, 0x0, byte(len(data))}
+	msg = append(msg, data...)
+	resp, err := l.device.Exchange(msg)
+	if err != nil {
+		return nil, mapLedgerErr(err, ledger.ErrRejectedSignature)
+	}
+	if !bytes.Equal(resp, hash) {
+		return nil, fmt.Errorf("returned hash %x does not match requested %x", resp, hash)
+	}
+
+	// then the signature of each address, the last one closing the request
+	sigs := make([][]byte, len(addressIndices))
+	for i, idx := range addressIndices {
+		p1 := byte(0x01)
+		if i == len(addressIndices)-1 {
+			p1 = 0x81
+		}
+		suffix, err := bip32Bytes([]uint32{idx}, 0)
+		if err != nil {
+			return nil, err
+		}
+		msg := []byte{ledgerCLA, ledgerINSSignHash, p1, 0x0, byte(len(suffix))}
+		msg = append(msg, suffix...)
+		sigs[i], err = l.device.Exchange(msg)
+		if err != nil {
+			return nil, mapLedgerErr(err, ledger.ErrRejectedSignature)
+		}
+	}
+	return sigs, nil
+}
+
+func (l *avaxLedger) Disconnect() error {
+	return l.device.Close()
+}
+
+// accountPath returns "m/44'/9000'/[account]'/0".
+func accountPath(account uint32) []uint32 {
+	return []uint32{44, avaxCoinType, account, 0}
+}
+
+// bip32Bytes encodes [path], hardening its first [hardenCount] indices.
+func bip32Bytes(path []uint32, hardenCount int) ([]byte, error) {
+	if len(path) > maxBIP32Depth {
+		return nil, fmt.Errorf("maximum bip32 depth = %d", maxBIP32Depth)
+	}
+	b := make([]byte, 1+len(path)*4)
+	b[0] = byte(len(path))
+	for i, idx := range path {
+		if i < hardenCount {
+			idx |= 0x80000000
+		}
+		binary.BigEndian.PutUint32(b[1+i*4:], idx)
+	}
+	return b, nil
+}
+
+// mapLedgerErr maps the errors of the device to the ones of
+// avalanche-ledger-go, or to [rejected] if the user rejected the request.
+func mapLedgerErr(err error, rejected error) error {
+	errString := err.Error()
+	switch {
+	case strings.Contains(errString, "LedgerHID device") && strings.Contains(errString, "not found"):
+		return ledger.ErrLedgerNotConnected
+	case strings.Contains(errString, "Error code: 6e01"):
+		return ledger.ErrAvalancheAppNotExecuting
+	case strings.Contains(errString, "Error code: 6b0c"):
+		return ledger.ErrLedgerIsBlocked
+	case strings.Contains(errString, "APDU_CODE_CONDITIONS_NOT_SATISFIED"):
+		return rejected
+	}
+	return err
+}
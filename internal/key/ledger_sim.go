@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"fmt"
+	"sync"
+
+	ledger "github.com/ava-labs/avalanche-ledger-go"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+var _ LedgerDevice = &SimulatedLedger{}
+
+// SimulatedLedger emulates the Avalanche Ledger app in software with the
+// HD seed of a mnemonic, deriving and signing on "m/44'/9000'/[account]'/0/i"
+// like the device does, so that HardKey can be tested without one.
+type SimulatedLedger struct {
+	mu sync.Mutex
+
+	coin *hdkeychain.ExtendedKey
+	// accounts and their keys are derived on first use
+	accounts map[uint32]*hdkeychain.ExtendedKey
+	keys     map[uint32]map[uint32]*crypto.PrivateKeySECP256K1R
+
+	disconnected bool
+}
+
+// NewSimulatedLedger creates a simulated Ledger seeded with [mnemonic].
+func NewSimulatedLedger(mnemonic string) (*SimulatedLedger, error) {
+	coin, err := deriveCoinKey(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return &SimulatedLedger{
+		coin:     coin,
+		accounts: map[uint32]*hdkeychain.ExtendedKey{},
+		keys:     map[uint32]map[uint32]*crypto.PrivateKeySECP256K1R{},
+	}, nil
+}
+
+func (s *SimulatedLedger) ExtendedPublicKey(account uint32) ([]byte, []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.disconnected {
+		return nil, nil, ledger.ErrLedgerNotConnected
+	}
+	accountKey, err := s.account(account)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, err := accountKey.ECPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the device returns the uncompressed public key
+	return pk.SerializeUncompressed(), accountKey.ChainCode(), nil
+}
+
+func (s *SimulatedLedger) SignHash(account uint32, hash []byte, addressIndices []uint32) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.disconnected {
+		return nil, ledger.ErrLedgerNotConnected
+	}
+	// the device only signs hashes
+	if len(hash) != hashing.HashLen {
+		return nil, fmt.Errorf("%w: hash of %d bytes", ErrInvalidType, len(hash))
+	}
+	sigs := make([][]byte, len(addressIndices))
+	for i, idx := range addressIndices {
+		k, err := s.key(account, idx)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i], err = k.SignHash(hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sigs, nil
+}
+
+func (s *SimulatedLedger) Disconnect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.disconnected = true
+	return nil
+}
+
+func (s *SimulatedLedger) account(account uint32) (*hdkeychain.ExtendedKey, error) {
+	if accountKey, ok := s.accounts[account]; ok {
+		return accountKey, nil
+	}
+	accountKey, err := deriveAccountKey(s.coin, account)
+	if err != nil {
+		return nil, err
+	}
+	s.accounts[account] = accountKey
+	s.keys[account] = map[uint32]*crypto.PrivateKeySECP256K1R{}
+	return accountKey, nil
+}
+
+func (s *SimulatedLedger) key(account uint32, idx uint32) (*crypto.PrivateKeySECP256K1R, error) {
+	accountKey, err := s.account(account)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := s.keys[account][idx]; ok {
+		return k, nil
+	}
+	k, err := deriveChildKey(accountKey, idx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys[account][idx] = k
+	return k, nil
+}
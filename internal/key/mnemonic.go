@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// 24 words
+	mnemonicEntropyBits = 256
+
+	// ref. https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+	avaxCoinType = 9000
+
+	// minimum number of words of a BIP39 mnemonic
+	minMnemonicWords = 12
+)
+
+var (
+	ErrInvalidMnemonic       = errors.New("invalid mnemonic")
+	ErrInvalidNumAddresses   = errors.New("invalid number of addresses")
+	ErrMnemonicAndPrivKey    = errors.New("can't set both a mnemonic and a private key")
+	errUnexpectedPublicDeriv = errors.New("unexpected public key derivation")
+)
+
+// NewMnemonic generates a new 24-word BIP39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// normalizeMnemonic joins the words of [mnemonic] with single spaces,
+// so that a phrase written over several lines can be loaded.
+func normalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(mnemonic), " ")
+}
+
+// DerivePrivateKeys derives the first [n] keys of [mnemonic] on the
+// Avalanche path "m/44'/9000'/0'/0/i", as the Avalanche wallet does.
+func DerivePrivateKeys(mnemonic string, n uint32) ([]*crypto.PrivateKeySECP256K1R, error) {
+	if n == 0 {
+		return nil, ErrInvalidNumAddresses
+	}
+	coin, err := deriveCoinKey(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	account, err := deriveAccountKey(coin, 0)
+	if err != nil {
+		return nil, err
+	}
+	privKeys := make([]*crypto.PrivateKeySECP256K1R, n)
+	for i := uint32(0); i < n; i++ {
+		privKeys[i], err = deriveChildKey(account, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return privKeys, nil
+}
+
+// deriveCoinKey returns the extended key of [mnemonic] on "m/44'/9000'".
+func deriveCoinKey(mnemonic string) (*hdkeychain.ExtendedKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(normalizeMnemonic(mnemonic), "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMnemonic, err)
+	}
+	// the network params only set the serialization version
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	purpose, err := master.Derive(hdkeychain.HardenedKeyStart + 44)
+	if err != nil {
+		return nil, err
+	}
+	return purpose.Derive(hdkeychain.HardenedKeyStart + avaxCoinType)
+}
+
+// deriveAccountKey returns the extended key on "m/44'/9000'/[account]'/0",
+// the parent of the external addresses of [account], from [coin].
+func deriveAccountKey(coin *hdkeychain.ExtendedKey, account uint32) (*hdkeychain.ExtendedKey, error) {
+	accountKey, err := coin.Derive(hdkeychain.HardenedKeyStart + account)
+	if err != nil {
+		return nil, err
+	}
+	return accountKey.Derive(0)
+}
+
+// deriveChildKey returns the private key of address [i] of [account].
+func deriveChildKey(account *hdkeychain.ExtendedKey, i uint32) (*crypto.PrivateKeySECP256K1R, error) {
+	child, err := account.Derive(i)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, err := child.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnexpectedPublicDeriv, err)
+	}
+	rpk, err := keyFactory.ToPrivateKey(ecKey.Serialize())
+	if err != nil {
+		return nil, err
+	}
+	privKey, ok := rpk.(*crypto.PrivateKeySECP256K1R)
+	if !ok {
+		return nil, ErrInvalidType
+	}
+	return privKey, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+func TestMnemonicKey(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := NewMnemonic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(strings.Fields(mnemonic)); n != 24 {
+		t.Fatalf("unexpected mnemonic of %d words", n)
+	}
+
+	m, err := NewSoft(fallbackNetworkID, WithMnemonic(mnemonic), WithNumAddresses(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.P()) != 3 || len(m.Addresses()) != 3 {
+		t.Fatalf("unexpected addresses %v", m.P())
+	}
+	if m.Addresses()[0] == m.Addresses()[1] {
+		t.Fatal("derived the same address twice")
+	}
+
+	// a phrase written over several lines loads the same keys
+	keyPath := filepath.Join(t.TempDir(), "key.mnemonic")
+	if err := m.Save(keyPath); err != nil {
+		t.Fatal(err)
+	}
+	m2, err := LoadSoft(fallbackNetworkID, keyPath, WithNumAddresses(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, addr := range m.Addresses() {
+		if m2.Addresses()[i] != addr {
+			t.Fatalf("loaded address %d unexpected %s, expected %s", i, m2.Addresses()[i], addr)
+		}
+	}
+	words := strings.Fields(mnemonic)
+	m3, err := NewSoft(fallbackNetworkID, WithMnemonic(strings.Join(words[:12], "\n")+"\n"+strings.Join(words[12:], " ")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m3.Addresses()[0] != m.Addresses()[0] {
+		t.Fatalf("unexpected address %s, expected %s", m3.Addresses()[0], m.Addresses()[0])
+	}
+
+	// any derived address can sign
+	hash := hashing.ComputeHash256([]byte("subnet-cli"))
+	sigs, err := m.SignHash(hash, m.Addresses()[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 2 {
+		t.Fatalf("unexpected %d signatures", len(sigs))
+	}
+	if _, err := m3.SignHash(hash, m.Addresses()[1:2]); !errors.Is(err, ErrCantSpend) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrCantSpend)
+	}
+
+	words[0] = "subnet"
+	if _, err := NewSoft(fallbackNetworkID, WithMnemonic(strings.Join(words, " "))); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidMnemonic)
+	}
+	if _, err := NewSoft(fallbackNetworkID, WithMnemonic(mnemonic), WithPrivateKeyEncoded(EwoqPrivateKey)); !errors.Is(err, ErrMnemonicAndPrivKey) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrMnemonicAndPrivKey)
+	}
+}
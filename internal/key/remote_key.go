@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// signed once to recover the public key of the primary address,
+// which the remote signer does not expose
+const ethAddressMessage = "subnet-cli C-Chain address"
+
+// The remote signer protocol is JSON over HTTP:
+//
+//	GET  /v1/addresses
+//	  -> {"addresses": ["<ids.ShortID>", ...]}
+//	POST /v1/sign-hash {"hash": "0x<32 bytes>", "signers": ["<ids.ShortID>", ...]}
+//	  -> {"signatures": ["0x<65 bytes>", ...]}
+//
+// The signatures are recoverable secp256k1 signatures of the hash, in the
+// order of the signers, hex-encoded with a "0x" prefix. Errors are returned as {"error": "..."} with a
+// non-2xx status. If a token is set, each request carries it in an
+// "Authorization: Bearer <token>" header.
+const (
+	remoteAddressesPath = "/v1/addresses"
+	remoteSignHashPath  = "/v1/sign-hash"
+
+	defaultRemoteTimeout = time.Minute
+)
+
+var ErrRemoteSigner = errors.New("remote signer error")
+
+type remoteAddressesResponse struct {
+	Addresses []ids.ShortID `json:"addresses"`
+}
+
+type remoteSignHashRequest struct {
+	Hash    string        `json:"hash"`
+	Signers []ids.ShortID `json:"signers"`
+}
+
+type remoteSignHashResponse struct {
+	Signatures []string `json:"signatures"`
+}
+
+type remoteErrorResponse struct {
+	Error string `json:"error"`
+}
+
+var _ Key = &RemoteKey{}
+
+// RemoteKey delegates signing to a remote signing service (e.g., a bridge
+// to an HSM or KMS), so that no private key is held locally.
+type RemoteKey struct {
+	uri     string
+	token   string
+	timeout time.Duration
+	cli     *http.Client
+
+	pAddrs       []string
+	shortAddrs   []ids.ShortID
+	shortAddrMap map[ids.ShortID]struct{}
+
+	ethAddr *common.Address
+}
+
+type ROp struct {
+	token   string
+	timeout time.Duration
+}
+
+type ROpOption func(*ROp)
+
+func (rop *ROp) applyOpts(opts []ROpOption) {
+	for _, opt := range opts {
+		opt(rop)
+	}
+}
+
+// To authenticate to the remote signer with a bearer token.
+func WithAuthToken(token string) ROpOption {
+	return func(rop *ROp) {
+		rop.token = token
+	}
+}
+
+// To time out each request to the remote signer.
+func WithRemoteTimeout(d time.Duration) ROpOption {
+	return func(rop *ROp) {
+		rop.timeout = d
+	}
+}
+
+// NewRemote connects to the remote signer at [uri] and fetches its addresses.
+func NewRemote(networkID uint32, uri string, opts ...ROpOption) (*RemoteKey, error) {
+	ret := &ROp{timeout: defaultRemoteTimeout}
+	ret.applyOpts(opts)
+
+	k := &RemoteKey{
+		uri:     strings.TrimSuffix(uri, "/"),
+		token:   ret.token,
+		timeout: ret.timeout,
+		cli:     &http.Client{},
+	}
+	resp := new(remoteAddressesResponse)
+	if err := k.do(http.MethodGet, remoteAddressesPath, nil, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Addresses) == 0 {
+		return nil, fmt.Errorf("%w: no addresses", ErrRemoteSigner)
+	}
+
+	hrp := getHRP(networkID)
+	k.pAddrs = make([]string, len(resp.Addresses))
+	k.shortAddrs = resp.Addresses
+	k.shortAddrMap = make(map[ids.ShortID]struct{}, len(resp.Addresses))
+	for i, addr := range resp.Addresses {
+		var err error
+		k.pAddrs[i], err = address.Format("P", hrp, addr[:])
+		if err != nil {
+			return nil, err
+		}
+		k.shortAddrMap[addr] = struct{}{}
+	}
+	zap.L().Info("connected to remote signer",
+		zap.String("uri", k.uri),
+		zap.Strings("addresses", k.pAddrs),
+	)
+	return k, nil
+}
+
+func (r *RemoteKey) P() []string { return r.pAddrs }
+
+func (r *RemoteKey) Addresses() []ids.ShortID { return r.shortAddrs }
+
+func (r *RemoteKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
+	totalBalanceToSpend uint64,
+	inputs []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+) {
+	return spendUTXOs(r, outputs, opts)
+}
+
+func (r *RemoteKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
+	return matchOwners(r.shortAddrMap, owners, time)
+}
+
+// Sign transaction with the remote signer
+//
+// This is a slightly modified version of *platformvm.Tx.Sign().
+func (r *RemoteKey) Sign(pTx *txs.Tx, signers [][]ids.ShortID) error {
+	unsignedBytes, hash, err := UnsignedHash(pTx)
+	if err != nil {
+		return err
+	}
+	sigs, err := SignSigners(r, hash, signers)
+	if err != nil {
+		return err
+	}
+	return AttachCredentials(pTx, unsignedBytes, signers, sigs)
+}
+
+// SignHash asks the remote signer to sign [hash] with each of [signers],
+// and verifies that each signature recovers to its signer.
+func (r *RemoteKey) SignHash(hash []byte, signers []ids.ShortID) ([][]byte, error) {
+	for _, signer := range signers {
+		if _, ok := r.shortAddrMap[signer]; !ok {
+			return nil, ErrCantSpend
+		}
+	}
+	resp := new(remoteSignHashResponse)
+	if err := r.do(http.MethodPost, remoteSignHashPath, &remoteSignHashRequest{
+		Hash:    "0x" + hex.EncodeToString(hash),
+		Signers: signers,
+	}, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Signatures) != len(signers) {
+		return nil, fmt.Errorf("%w: got %d signatures, expected %d", ErrRemoteSigner, len(resp.Signatures), len(signers))
+	}
+
+	sigs := make([][]byte, len(signers))
+	for i, encSig := range resp.Signatures {
+		sig, err := decodeHex(encSig)
+		if err != nil {
+			return nil, fmt.Errorf("%w: signature %d (%v)", ErrRemoteSigner, i, err)
+		}
+		pk, err := keyFactory.RecoverHashPublicKey(hash, sig)
+		if err != nil || pk.Address() != signers[i] {
+			return nil, fmt.Errorf("%w: invalid signature for %s", ErrRemoteSigner, signers[i])
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
+
+// EthAddress returns the C-Chain address of the primary remote address,
+// recovered from the signature of a fixed message.
+func (r *RemoteKey) EthAddress() (common.Address, error) {
+	if r.ethAddr != nil {
+		return *r.ethAddr, nil
+	}
+	hash := hashing.ComputeHash256([]byte(ethAddressMessage))
+	sigs, err := r.SignHash(hash, r.shortAddrs[:1])
+	if err != nil {
+		return common.Address{}, err
+	}
+	pk, err := keyFactory.RecoverHashPublicKey(hash, sigs[0])
+	if err != nil {
+		return common.Address{}, err
+	}
+	pubKey, ok := pk.(*crypto.PublicKeySECP256K1R)
+	if !ok {
+		return common.Address{}, ErrInvalidType
+	}
+	ethAddr := ethcrypto.PubkeyToAddress(*pubKey.ToECDSA())
+	r.ethAddr = &ethAddr
+	return ethAddr, nil
+}
+
+// do sends [req] (if not nil) to [path] and decodes the response into [resp].
+func (r *RemoteKey) do(method string, path string, req interface{}, resp interface{}) error {
+	var body io.Reader
+	if req != nil {
+		b, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	hreq, err := http.NewRequestWithContext(ctx, method, r.uri+path, body)
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		hreq.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	hresp, err := r.cli.Do(hreq)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRemoteSigner, err)
+	}
+	defer hresp.Body.Close()
+
+	b, err := io.ReadAll(hresp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRemoteSigner, err)
+	}
+	if hresp.StatusCode/100 != 2 {
+		eresp := new(remoteErrorResponse)
+		if json.Unmarshal(b, eresp) != nil || eresp.Error == "" {
+			eresp.Error = hresp.Status
+		}
+		return fmt.Errorf("%w: %s", ErrRemoteSigner, eresp.Error)
+	}
+	if err := json.Unmarshal(b, resp); err != nil {
+		return fmt.Errorf("%w: %v", ErrRemoteSigner, err)
+	}
+	return nil
+}
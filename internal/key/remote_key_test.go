@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestRemoteKey(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(NewSignerHandler(m, "secret"))
+	defer srv.Close()
+
+	if _, err := NewRemote(fallbackNetworkID, srv.URL, WithAuthToken("wrong")); !errors.Is(err, ErrRemoteSigner) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrRemoteSigner)
+	}
+	r, err := NewRemote(fallbackNetworkID, srv.URL+"/", WithAuthToken("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.P()[0] != ewoqPChainAddr {
+		t.Fatalf("unexpected P-Chain address %q, expected %q", r.P()[0], ewoqPChainAddr)
+	}
+
+	// signing remotely must match signing with the key
+	addr := r.Addresses()[0]
+	signers := [][]ids.ShortID{{addr}, {addr, addr}}
+	expected := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	if err := m.Sign(expected, signers); err != nil {
+		t.Fatal(err)
+	}
+	pTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	if err := r.Sign(pTx, signers); err != nil {
+		t.Fatal(err)
+	}
+	if pTx.ID() != expected.ID() {
+		t.Fatalf("unexpected tx ID %s, expected %s", pTx.ID(), expected.ID())
+	}
+
+	ethAddr, err := r.EthAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethAddr.Hex() != ewoqCChainAddr {
+		t.Fatalf("unexpected C-Chain address %s, expected %s", ethAddr.Hex(), ewoqCChainAddr)
+	}
+
+	if _, err := r.SignHash(make([]byte, 32), []ids.ShortID{ids.GenerateTestShortID()}); !errors.Is(err, ErrCantSpend) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrCantSpend)
+	}
+}
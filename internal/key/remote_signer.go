@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// maximum size of a sign request, far above a list of every signer
+const maxRemoteRequestSize = 1 << 20
+
+// NewSignerHandler returns the reference implementation of the remote
+// signer protocol (see RemoteKey), which signs with [k]. If [token] is
+// not empty, requests without it are rejected.
+func NewSignerHandler(k Key, token string) http.Handler {
+	s := &signerHandler{k: k, token: token}
+	mux := http.NewServeMux()
+	mux.HandleFunc(remoteAddressesPath, s.addresses)
+	mux.HandleFunc(remoteSignHashPath, s.signHash)
+	return s.authenticate(mux)
+}
+
+type signerHandler struct {
+	k     Key
+	token string
+}
+
+func (s *signerHandler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+				writeSignerError(w, http.StatusUnauthorized, "invalid token")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *signerHandler) addresses(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeSignerError(w, http.StatusMethodNotAllowed, "expected GET")
+		return
+	}
+	writeSignerResponse(w, &remoteAddressesResponse{Addresses: s.k.Addresses()})
+}
+
+func (s *signerHandler) signHash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeSignerError(w, http.StatusMethodNotAllowed, "expected POST")
+		return
+	}
+	req := new(remoteSignHashRequest)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRemoteRequestSize)).Decode(req); err != nil {
+		writeSignerError(w, http.StatusBadRequest, fmt.Sprintf("invalid request (%v)", err))
+		return
+	}
+	hash, err := decodeHex(req.Hash)
+	if err != nil || len(hash) != 32 {
+		writeSignerError(w, http.StatusBadRequest, "invalid hash (expected 32 bytes in hex)")
+		return
+	}
+	sigs, err := s.k.SignHash(hash, req.Signers)
+	if err != nil {
+		writeSignerError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	zap.L().Info("signed hash",
+		zap.String("hash", req.Hash),
+		zap.Int("signers", len(req.Signers)),
+		zap.String("remoteAddr", r.RemoteAddr),
+	)
+	resp := &remoteSignHashResponse{Signatures: make([]string, len(sigs))}
+	for i, sig := range sigs {
+		resp.Signatures[i] = "0x" + hex.EncodeToString(sig)
+	}
+	writeSignerResponse(w, resp)
+}
+
+func writeSignerResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		zap.L().Warn("failed to write signer response", zap.Error(err))
+	}
+}
+
+func writeSignerError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&remoteErrorResponse{Error: msg})
+}
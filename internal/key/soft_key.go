@@ -19,6 +19,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 )
 
@@ -36,7 +38,13 @@ type SoftKey struct {
 	privKeyRaw     []byte
 	privKeyEncoded string
 
-	pAddr string
+	// mnemonic the keys are derived from, empty for a single private key
+	mnemonic string
+	// privKeys are all the keys, starting with [privKey]
+	privKeys   []*crypto.PrivateKeySECP256K1R
+	privKeyMap map[ids.ShortID]*crypto.PrivateKeySECP256K1R
+
+	pAddrs []string
 
 	keyChain *secp256k1fx.Keychain
 }
@@ -54,6 +62,11 @@ var keyFactory = new(crypto.FactorySECP256K1R)
 type SOp struct {
 	privKey        *crypto.PrivateKeySECP256K1R
 	privKeyEncoded string
+
+	mnemonic     string
+	numAddresses uint32
+
+	passphrase func() ([]byte, error)
 }
 
 type SOpOption func(*SOp)
@@ -78,10 +91,46 @@ func WithPrivateKeyEncoded(privKey string) SOpOption {
 	}
 }
 
+// To create a new key SoftKey with the keys derived from a BIP39 mnemonic.
+func WithMnemonic(mnemonic string) SOpOption {
+	return func(sop *SOp) {
+		sop.mnemonic = normalizeMnemonic(mnemonic)
+	}
+}
+
+// To derive [n] addresses from the mnemonic (default to 1).
+func WithNumAddresses(n uint32) SOpOption {
+	return func(sop *SOp) {
+		sop.numAddresses = n
+	}
+}
+
+// To decrypt an encrypted key file with the passphrase returned by [f],
+// which is only called if the file is encrypted.
+func WithPassphrase(f func() ([]byte, error)) SOpOption {
+	return func(sop *SOp) {
+		sop.passphrase = f
+	}
+}
+
 func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
-	ret := &SOp{}
+	ret := &SOp{numAddresses: 1}
 	ret.applyOpts(opts)
 
+	// set via "WithMnemonic"
+	var privKeys []*crypto.PrivateKeySECP256K1R
+	if ret.mnemonic != "" {
+		if ret.privKey != nil || ret.privKeyEncoded != "" {
+			return nil, ErrMnemonicAndPrivKey
+		}
+		var err error
+		privKeys, err = DerivePrivateKeys(ret.mnemonic, ret.numAddresses)
+		if err != nil {
+			return nil, err
+		}
+		ret.privKey = privKeys[0]
+	}
+
 	// set via "WithPrivateKeyEncoded"
 	if len(ret.privKeyEncoded) > 0 {
 		privKey, err := decodePrivateKey(ret.privKeyEncoded)
@@ -121,34 +170,67 @@ func NewSoft(networkID uint32, opts ...SOpOption) (*SoftKey, error) {
 		return nil, ErrInvalidPrivateKeyEncoding
 	}
 
-	keyChain := secp256k1fx.NewKeychain()
-	keyChain.Add(privKey)
+	if len(privKeys) == 0 {
+		privKeys = []*crypto.PrivateKeySECP256K1R{privKey}
+	}
 
 	m := &SoftKey{
 		privKey:        privKey,
 		privKeyRaw:     privKey.Bytes(),
 		privKeyEncoded: privKeyEncoded,
 
-		keyChain: keyChain,
+		mnemonic:   ret.mnemonic,
+		privKeys:   privKeys,
+		privKeyMap: make(map[ids.ShortID]*crypto.PrivateKeySECP256K1R, len(privKeys)),
+		pAddrs:     make([]string, len(privKeys)),
+
+		keyChain: secp256k1fx.NewKeychain(),
 	}
 
 	// Parse HRP to create valid address
 	hrp := getHRP(networkID)
-	m.pAddr, err = address.Format("P", hrp, m.privKey.PublicKey().Address().Bytes())
-	if err != nil {
-		return nil, err
+	for i, pk := range privKeys {
+		m.keyChain.Add(pk)
+		m.privKeyMap[pk.PublicKey().Address()] = pk
+		m.pAddrs[i], err = address.Format("P", hrp, pk.PublicKey().Address().Bytes())
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return m, nil
 }
 
-// LoadSoft loads the private key from disk and creates the corresponding SoftKey.
-func LoadSoft(networkID uint32, keyPath string) (*SoftKey, error) {
+// LoadSoft loads the private key (or the BIP39 mnemonic) from disk and
+// creates the corresponding SoftKey. Encrypted key files are decrypted
+// with the passphrase set by WithPassphrase.
+func LoadSoft(networkID uint32, keyPath string, opts ...SOpOption) (*SoftKey, error) {
 	kb, err := os.ReadFile(keyPath)
 	if err != nil {
 		return nil, err
 	}
 
+	if isEncrypted(kb) {
+		ret := &SOp{}
+		ret.applyOpts(opts)
+		if ret.passphrase == nil {
+			return nil, ErrMissingPassphrase
+		}
+		passphrase, err := ret.passphrase()
+		if err != nil {
+			return nil, err
+		}
+		kb, err = decrypt(kb, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// a mnemonic is a phrase of words, unlike the encoded keys
+	if len(strings.Fields(string(kb))) >= minMnemonicWords {
+		return NewSoft(networkID, append([]SOpOption{WithMnemonic(string(kb))}, opts...)...)
+	}
+
 	// in case, it's already encoded
 	k, err := NewSoft(networkID, WithPrivateKeyEncoded(string(kb)))
 	if err == nil {
@@ -259,13 +341,35 @@ func (m *SoftKey) Encode() string {
 	return m.privKeyEncoded
 }
 
-// Saves the private key to disk with hex encoding.
+// Returns the BIP39 mnemonic the keys are derived from, if any.
+func (m *SoftKey) Mnemonic() string {
+	return m.mnemonic
+}
+
+// Saves the private key to disk with hex encoding,
+// or the mnemonic if the keys are derived from one.
 func (m *SoftKey) Save(p string) error {
-	k := hex.EncodeToString(m.privKeyRaw)
-	return os.WriteFile(p, []byte(k), fsModeWrite)
+	return os.WriteFile(p, m.fileContent(), fsModeWrite)
 }
 
-func (m *SoftKey) P() []string { return []string{m.pAddr} }
+// Saves the private key (or the mnemonic) to disk,
+// encrypted with a key derived from [passphrase].
+func (m *SoftKey) SaveEncrypted(p string, passphrase []byte) error {
+	b, err := encrypt(m.fileContent(), passphrase)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, b, fsModeWrite)
+}
+
+func (m *SoftKey) fileContent() []byte {
+	if m.mnemonic != "" {
+		return []byte(m.mnemonic + "\n")
+	}
+	return []byte(hex.EncodeToString(m.privKeyRaw))
+}
+
+func (m *SoftKey) P() []string { return m.pAddrs }
 
 func (m *SoftKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
 	totalBalanceToSpend uint64,
@@ -324,7 +428,11 @@ func (m *SoftKey) spend(output *avax.UTXO, time uint64) (
 const fsModeWrite = 0o600
 
 func (m *SoftKey) Addresses() []ids.ShortID {
-	return []ids.ShortID{m.privKey.PublicKey().Address()}
+	addrs := make([]ids.ShortID, len(m.privKeys))
+	for i, pk := range m.privKeys {
+		addrs[i] = pk.PublicKey().Address()
+	}
+	return addrs
 }
 
 func (m *SoftKey) Sign(pTx *txs.Tx, signers [][]ids.ShortID) error {
@@ -332,17 +440,38 @@ func (m *SoftKey) Sign(pTx *txs.Tx, signers [][]ids.ShortID) error {
 	for i, inputSigners := range signers {
 		privsigners[i] = make([]*crypto.PrivateKeySECP256K1R, len(inputSigners))
 		for j, signer := range inputSigners {
-			if signer != m.privKey.PublicKey().Address() {
+			pk, ok := m.privKeyMap[signer]
+			if !ok {
 				// Should never happen
 				return ErrCantSpend
 			}
-			privsigners[i][j] = m.privKey
+			privsigners[i][j] = pk
 		}
 	}
 
 	return pTx.Sign(txs.Codec, privsigners)
 }
 
+func (m *SoftKey) SignHash(hash []byte, signers []ids.ShortID) ([][]byte, error) {
+	sigs := make([][]byte, len(signers))
+	for i, signer := range signers {
+		pk, ok := m.privKeyMap[signer]
+		if !ok {
+			return nil, ErrCantSpend
+		}
+		sig, err := pk.SignHash(hash)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
+
+func (m *SoftKey) EthAddress() (common.Address, error) {
+	return ethcrypto.PubkeyToAddress(m.privKey.ToECDSA().PublicKey), nil
+}
+
 func (m *SoftKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
 	indices, privs, ok := m.keyChain.Match(owners, time)
 	pks := make([]ids.ShortID, len(privs))
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrWatchOnly      = errors.New("watch-only key can't sign, export the unsigned transaction instead")
+	ErrInvalidAddress = errors.New("invalid P-Chain address")
+)
+
+var _ Key = &WatchKey{}
+
+// WatchKey holds P-Chain addresses without their private keys, so that
+// transactions spending from them can be built (e.g., to select UTXOs
+// and estimate fees) and handed to an approver to sign.
+type WatchKey struct {
+	pAddrs       []string
+	shortAddrs   []ids.ShortID
+	shortAddrMap map[ids.ShortID]struct{}
+}
+
+// NewWatch creates a watch-only key of [pAddrs] (e.g., "P-fuji1...").
+func NewWatch(networkID uint32, pAddrs []string) (*WatchKey, error) {
+	if len(pAddrs) == 0 {
+		return nil, ErrNoKeys
+	}
+	hrp := getHRP(networkID)
+	w := &WatchKey{
+		shortAddrMap: make(map[ids.ShortID]struct{}, len(pAddrs)),
+	}
+	for _, paddr := range pAddrs {
+		chain, addrHRP, b, err := address.Parse(paddr)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q (%v)", ErrInvalidAddress, paddr, err)
+		}
+		if chain != "P" || addrHRP != hrp {
+			return nil, fmt.Errorf("%w %q (expected P-%s...)", ErrInvalidAddress, paddr, hrp)
+		}
+		addr, err := ids.ToShortID(b)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q (%v)", ErrInvalidAddress, paddr, err)
+		}
+		if _, ok := w.shortAddrMap[addr]; ok {
+			continue
+		}
+		w.shortAddrMap[addr] = struct{}{}
+		w.shortAddrs = append(w.shortAddrs, addr)
+		w.pAddrs = append(w.pAddrs, paddr)
+	}
+	return w, nil
+}
+
+func (w *WatchKey) P() []string { return w.pAddrs }
+
+func (w *WatchKey) Addresses() []ids.ShortID { return w.shortAddrs }
+
+func (w *WatchKey) Spends(outputs []*avax.UTXO, opts ...OpOption) (
+	totalBalanceToSpend uint64,
+	inputs []*avax.TransferableInput,
+	signers [][]ids.ShortID,
+) {
+	return spendUTXOs(w, outputs, opts)
+}
+
+func (w *WatchKey) Match(owners *secp256k1fx.OutputOwners, time uint64) ([]uint32, []ids.ShortID, bool) {
+	return matchOwners(w.shortAddrMap, owners, time)
+}
+
+func (w *WatchKey) Sign(*txs.Tx, [][]ids.ShortID) error { return ErrWatchOnly }
+
+func (w *WatchKey) SignHash([]byte, []ids.ShortID) ([][]byte, error) { return nil, ErrWatchOnly }
+
+// EthAddress can't be derived from a P-Chain address.
+func (w *WatchKey) EthAddress() (common.Address, error) { return common.Address{}, ErrWatchOnly }
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package key
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestWatchKey(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewSoft(fallbackNetworkID, WithPrivateKeyEncoded(EwoqPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWatch(fallbackNetworkID, []string{ewoqPChainAddr, ewoqPChainAddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Addresses()) != 1 || w.Addresses()[0] != m.Addresses()[0] {
+		t.Fatalf("unexpected addresses %v, expected %v", w.Addresses(), m.Addresses())
+	}
+
+	owners := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID(), m.Addresses()[0]},
+	}
+	indices, signers, ok := w.Match(owners, 0)
+	if !ok || len(indices) != 1 || indices[0] != 1 || signers[0] != m.Addresses()[0] {
+		t.Fatalf("unexpected match %v %v %v", indices, signers, ok)
+	}
+
+	pTx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{Owner: &secp256k1fx.OutputOwners{}}}
+	if err := w.Sign(pTx, [][]ids.ShortID{signers}); !errors.Is(err, ErrWatchOnly) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrWatchOnly)
+	}
+
+	if _, err := NewWatch(constants.MainnetID, []string{ewoqPChainAddr}); !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidAddress)
+	}
+	if _, err := NewWatch(fallbackNetworkID, []string{"X" + ewoqPChainAddr[1:]}); !errors.Is(err, ErrInvalidAddress) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidAddress)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package spec implements the declarative description of a subnet.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidSpec = errors.New("invalid subnet spec")
+
+// Subnet is the desired state of a subnet, loaded from a YAML or JSON file.
+type Subnet struct {
+	// RawID is the ID of the subnet, or empty to create it.
+	RawID string `yaml:"id,omitempty" json:"id,omitempty"`
+	ID    ids.ID `yaml:"-" json:"-"`
+	// ControlKeys are the P-Chain addresses (e.g., "P-fuji1...")
+	// that control the subnet.
+	ControlKeys []string      `yaml:"controlKeys" json:"controlKeys"`
+	Threshold   uint32        `yaml:"threshold" json:"threshold"`
+	Validators  []*Validator  `yaml:"validators" json:"validators"`
+	Blockchains []*Blockchain `yaml:"blockchains" json:"blockchains"`
+}
+
+// Validator is a node that validates the subnet.
+type Validator struct {
+	RawNodeID string     `yaml:"nodeID" json:"nodeID"`
+	NodeID    ids.NodeID `yaml:"-" json:"-"`
+	Weight    uint64     `yaml:"weight" json:"weight"`
+	// End is the end of the validation period,
+	// or zero to validate until the end on the primary network.
+	End time.Time `yaml:"end,omitempty" json:"end,omitempty"`
+}
+
+// Blockchain is a blockchain of the subnet.
+type Blockchain struct {
+	Name    string `yaml:"name" json:"name"`
+	RawVMID string `yaml:"vmID" json:"vmID"`
+	VMID    ids.ID `yaml:"-" json:"-"`
+	// GenesisPath is relative to the spec file.
+	GenesisPath string `yaml:"genesisPath" json:"genesisPath"`
+}
+
+// Load loads and verifies the spec at [p]. JSON specs are
+// parsed as YAML, of which JSON is a subset.
+func Load(p string) (*Subnet, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+	s := new(Subnet)
+	if err := yaml.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	for _, bc := range s.Blockchains {
+		if bc.GenesisPath != "" && !filepath.IsAbs(bc.GenesisPath) {
+			bc.GenesisPath = filepath.Join(filepath.Dir(p), bc.GenesisPath)
+		}
+	}
+	if err := s.Verify(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetID records [id] as the "id" of the spec at [p], leaving the rest of
+// the file, including the comments of a YAML spec, as is.
+func SetID(p string, id ids.ID) error {
+	fi, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSpec, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: not a mapping", ErrInvalidSpec)
+	}
+	root := doc.Content[0]
+
+	// a JSON spec is a flow mapping, and stays JSON with quoted strings
+	var style yaml.Style
+	if root.Style&yaml.FlowStyle != 0 {
+		style = yaml.DoubleQuotedStyle
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: id.String(), Style: style}
+	found := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "id" {
+			root.Content[i+1] = value
+			found = true
+		}
+	}
+	if !found {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "id", Style: style}
+		root.Content = append([]*yaml.Node{key, value}, root.Content...)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	b = buf.Bytes()
+	if style == yaml.DoubleQuotedStyle {
+		var out bytes.Buffer
+		if err := json.Indent(&out, b, "", "  "); err != nil {
+			return err
+		}
+		b = out.Bytes()
+	}
+	return os.WriteFile(p, b, fi.Mode().Perm())
+}
+
+// Owners returns the control keys and threshold of the subnet.
+func (s *Subnet) Owners() (*secp256k1fx.OutputOwners, error) {
+	addrs := make([]ids.ShortID, len(s.ControlKeys))
+	for i, raddr := range s.ControlKeys {
+		addr, err := address.ParseToID(raddr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: control key %q (%v)", ErrInvalidSpec, raddr, err)
+		}
+		addrs[i] = addr
+	}
+	ids.SortShortIDs(addrs)
+	return &secp256k1fx.OutputOwners{Threshold: s.Threshold, Addrs: addrs}, nil
+}
+
+// Verify parses the IDs of the spec, and returns
+// an error if the spec can't be applied.
+func (s *Subnet) Verify() (err error) {
+	if s.RawID != "" {
+		s.ID, err = ids.FromString(s.RawID)
+		if err != nil {
+			return fmt.Errorf("%w: subnet ID %q (%v)", ErrInvalidSpec, s.RawID, err)
+		}
+	}
+	owners, err := s.Owners()
+	if err != nil {
+		return err
+	}
+	if len(owners.Addrs) == 0 {
+		return fmt.Errorf("%w: no control keys", ErrInvalidSpec)
+	}
+	if err := owners.Verify(); err != nil {
+		return fmt.Errorf("%w: control keys (%v)", ErrInvalidSpec, err)
+	}
+
+	nodeIDs := map[ids.NodeID]struct{}{}
+	for _, vdr := range s.Validators {
+		vdr.NodeID, err = ids.NodeIDFromString(vdr.RawNodeID)
+		if err != nil {
+			return fmt.Errorf("%w: node ID %q (%v)", ErrInvalidSpec, vdr.RawNodeID, err)
+		}
+		if _, ok := nodeIDs[vdr.NodeID]; ok {
+			return fmt.Errorf("%w: duplicate validator %s", ErrInvalidSpec, vdr.NodeID)
+		}
+		nodeIDs[vdr.NodeID] = struct{}{}
+		if vdr.Weight == 0 {
+			return fmt.Errorf("%w: validator %s without weight", ErrInvalidSpec, vdr.NodeID)
+		}
+	}
+
+	names := map[string]struct{}{}
+	for _, bc := range s.Blockchains {
+		if bc.Name == "" || bc.GenesisPath == "" {
+			return fmt.Errorf("%w: blockchain requires a name, VM ID and genesis path", ErrInvalidSpec)
+		}
+		bc.VMID, err = ids.FromString(bc.RawVMID)
+		if err != nil {
+			return fmt.Errorf("%w: VM ID %q of blockchain %q (%v)", ErrInvalidSpec, bc.RawVMID, bc.Name, err)
+		}
+		if _, ok := names[bc.Name]; ok {
+			return fmt.Errorf("%w: duplicate blockchain %q", ErrInvalidSpec, bc.Name)
+		}
+		names[bc.Name] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const testSpec = `
+id: 24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1
+controlKeys:
+  - P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p
+threshold: 1
+validators:
+  - nodeID: NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg
+    weight: 1000
+    end: 2023-10-01T00:00:00Z
+blockchains:
+  - name: spacesvm
+    vmID: sqja3uK17MJxfC7AN8nGadBw9JK5BcrsNwNynsqP5Gih8M5Bm
+    genesisPath: spacesvm.genesis
+`
+
+const testJSONSpec = `{
+  "controlKeys": ["P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"],
+  "threshold": 2,
+  "validators": []
+}`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	p := filepath.Join(dir, "subnet.yaml")
+	if err := os.WriteFile(p, []byte(testSpec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID.String() != "24tZhrm8j8GCJRE9PomW8FaeqbgGS4UAQjJnqqn8pq5NwYSYV1" {
+		t.Fatalf("unexpected subnet ID %s", s.ID)
+	}
+	if len(s.Validators) != 1 || s.Validators[0].Weight != 1000 {
+		t.Fatalf("unexpected validators %+v", s.Validators)
+	}
+	if !s.Validators[0].End.Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected validator end %v", s.Validators[0].End)
+	}
+	if s.Blockchains[0].GenesisPath != filepath.Join(dir, "spacesvm.genesis") {
+		t.Fatalf("unexpected genesis path %q", s.Blockchains[0].GenesisPath)
+	}
+
+	// 2-of-1 control keys can't be satisfied
+	jp := filepath.Join(dir, "subnet.json")
+	if err := os.WriteFile(jp, []byte(testJSONSpec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(jp); !errors.Is(err, ErrInvalidSpec) {
+		t.Fatalf("unexpected error %v, expected %v", err, ErrInvalidSpec)
+	}
+}
+
+func TestSetID(t *testing.T) {
+	t.Parallel()
+
+	yamlSpec := `# the subnet of the test
+controlKeys:
+  - P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p
+threshold: 1
+`
+	jsonSpec := `{
+  "controlKeys": ["P-custom18jma8ppw3nhx5r4ap8clazz0dps7rv5u9xde7p"],
+  "threshold": 1
+}`
+	tt := []struct {
+		name string
+		spec string
+	}{
+		{name: "subnet.yaml", spec: yamlSpec},
+		{name: "subnet.json", spec: jsonSpec},
+		{name: "empty-id.yaml", spec: "id: \"\"\n" + yamlSpec},
+	}
+	for i, tv := range tt {
+		p := filepath.Join(t.TempDir(), tv.name)
+		if err := os.WriteFile(p, []byte(tv.spec), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		id := ids.GenerateTestID()
+		if err := SetID(p, id); err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		s, err := Load(p)
+		if err != nil {
+			t.Fatalf("#%d(%s): %v", i, tv.name, err)
+		}
+		if s.ID != id {
+			t.Fatalf("#%d(%s): unexpected subnet ID %s, expected %s", i, tv.name, s.ID, id)
+		}
+		if len(s.ControlKeys) != 1 || s.Threshold != 1 {
+			t.Fatalf("#%d(%s): unexpected control keys %v (threshold %d)", i, tv.name, s.ControlKeys, s.Threshold)
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Ext(p) == ".json" && !json.Valid(b) {
+			t.Fatalf("#%d(%s): invalid JSON spec %s", i, tv.name, b)
+		}
+		if filepath.Ext(p) == ".yaml" && !bytes.Contains(b, []byte("# the subnet of the test")) {
+			t.Fatalf("#%d(%s): comment not kept in %s", i, tv.name, b)
+		}
+	}
+}